@@ -48,26 +48,64 @@ func main() {
 	var authenticator auth.Authenticator
 	authenticator = auth.NewStaticTokenAuth(cfg.Auth.AdminToken, cfg.Auth.UserToken)
 
-	teamService := usecase.NewTeamService(repo, txManager, logger)
+	teamStrategies := make(map[string]domain.SelectionStrategy, len(cfg.Assignment.TeamStrategies))
+	for teamName, strategy := range cfg.Assignment.TeamStrategies {
+		teamStrategies[teamName] = domain.SelectionStrategy(strategy)
+	}
+	pairing := usecase.PairingHistoryOptions{
+		Lookback: time.Duration(cfg.Assignment.PairingHistory.LookbackDays) * 24 * time.Hour,
+		HalfLife: time.Duration(cfg.Assignment.PairingHistory.HalfLifeDays) * 24 * time.Hour,
+	}
+	selectors, err := usecase.NewReviewerSelectors(repo, domain.SelectionStrategy(cfg.Assignment.Strategy), teamStrategies, pairing)
+	if err != nil {
+		logger.Error("Failed to initialize reviewer selectors", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	assigner, err := usecase.NewReviewerAssigner(repo, selectors, domain.CapacityPolicy(cfg.Assignment.CapacityPolicy))
+	if err != nil {
+		logger.Error("Failed to initialize reviewer assigner", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	teamService := usecase.NewTeamService(repo, txManager, assigner, logger)
 	userService := usecase.NewUserService(repo, txManager, logger)
-	prService := usecase.NewPRService(repo, txManager, logger)
+	prService := usecase.NewPRService(repo, txManager, assigner, logger)
+	codeOwnersService := usecase.NewCodeOwnersService(repo, txManager, logger)
 	metricsService := usecase.NewMetricsService(repo, txManager, logger)
 
 	teamHandler := handlers.NewTeamHandler(teamService, logger)
 	userHandler := handlers.NewUserHandler(userService, logger)
 	prHandler := handlers.NewPRHandler(prService, logger)
+	codeOwnersHandler := handlers.NewCodeOwnersHandler(codeOwnersService, logger)
 
 	srv := http.NewServer(
 		cfg,
 		teamHandler,
 		userHandler,
 		prHandler,
+		codeOwnersHandler,
 		metricsService,
 		authenticator,
 		metricsCollector,
 		logger,
 	)
 
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	if cfg.OutOfOffice.JobEnabled {
+		oooJob := usecase.NewOutOfOfficeJob(repo, teamService, time.Duration(cfg.OutOfOffice.JobInterval)*time.Second, logger)
+		go oooJob.Run(jobCtx)
+		logger.Info("Out of office job started", slog.Int("interval_seconds", cfg.OutOfOffice.JobInterval))
+	}
+
+	if cfg.ReviewSLA.JobEnabled {
+		slaJob := usecase.NewReviewSLAJob(repo, prService, usecase.NewLogNotifier(logger), time.Duration(cfg.ReviewSLA.JobInterval)*time.Second, logger)
+		go slaJob.Run(jobCtx)
+		logger.Info("Review SLA job started", slog.Int("interval_seconds", cfg.ReviewSLA.JobInterval))
+	}
+
 	go func() {
 		logger.Info("Starting HTTP server", slog.String("address", fmt.Sprintf(":%d", cfg.Server.Port)))
 		if err := srv.Start(); err != nil {
@@ -81,6 +119,8 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -8,10 +8,13 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Storage  StorageConfig
-	Auth     AuthConfig
-	LogLevel string
+	Server      ServerConfig
+	Storage     StorageConfig
+	Auth        AuthConfig
+	Assignment  AssignmentConfig
+	OutOfOffice OutOfOfficeConfig
+	ReviewSLA   ReviewSLAConfig
+	LogLevel    string
 }
 
 type ServerConfig struct {
@@ -32,6 +35,28 @@ type AuthConfig struct {
 	UserToken  string
 }
 
+type AssignmentConfig struct {
+	Strategy       string
+	TeamStrategies map[string]string
+	CapacityPolicy string
+	PairingHistory PairingHistoryConfig
+}
+
+type PairingHistoryConfig struct {
+	LookbackDays int
+	HalfLifeDays int
+}
+
+type OutOfOfficeConfig struct {
+	JobEnabled  bool
+	JobInterval int
+}
+
+type ReviewSLAConfig struct {
+	JobEnabled  bool
+	JobInterval int
+}
+
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -48,6 +73,14 @@ func Load() (*Config, error) {
 	viper.SetDefault("auth.type", "static")
 	viper.SetDefault("auth.admin_token", "admin-secret-token")
 	viper.SetDefault("auth.user_token", "user-secret-token")
+	viper.SetDefault("assignment.strategy", "random")
+	viper.SetDefault("assignment.capacity_policy", "fewer")
+	viper.SetDefault("assignment.pairing_history.lookback_days", 90)
+	viper.SetDefault("assignment.pairing_history.half_life_days", 14)
+	viper.SetDefault("out_of_office.job_enabled", false)
+	viper.SetDefault("out_of_office.job_interval", 60)
+	viper.SetDefault("review_sla.job_enabled", false)
+	viper.SetDefault("review_sla.job_interval", 300)
 	viper.SetDefault("log_level", "info")
 
 	viper.AutomaticEnv()
@@ -77,6 +110,23 @@ func Load() (*Config, error) {
 			AdminToken: viper.GetString("auth.admin_token"),
 			UserToken:  viper.GetString("auth.user_token"),
 		},
+		Assignment: AssignmentConfig{
+			Strategy:       viper.GetString("assignment.strategy"),
+			TeamStrategies: viper.GetStringMapString("assignment.team_strategies"),
+			CapacityPolicy: viper.GetString("assignment.capacity_policy"),
+			PairingHistory: PairingHistoryConfig{
+				LookbackDays: viper.GetInt("assignment.pairing_history.lookback_days"),
+				HalfLifeDays: viper.GetInt("assignment.pairing_history.half_life_days"),
+			},
+		},
+		OutOfOffice: OutOfOfficeConfig{
+			JobEnabled:  viper.GetBool("out_of_office.job_enabled"),
+			JobInterval: viper.GetInt("out_of_office.job_interval"),
+		},
+		ReviewSLA: ReviewSLAConfig{
+			JobEnabled:  viper.GetBool("review_sla.job_enabled"),
+			JobInterval: viper.GetInt("review_sla.job_interval"),
+		},
 		LogLevel: viper.GetString("log_level"),
 	}
 
@@ -1,24 +1,39 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type ErrorCode string
 
 const (
-	ErrCodeTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrCodePRExists    ErrorCode = "PR_EXISTS"
-	ErrCodePRMerged    ErrorCode = "PR_MERGED"
-	ErrCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrCodeNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrCodeNotFound    ErrorCode = "NOT_FOUND"
-	ErrCodeInternal    ErrorCode = "INTERNAL_ERROR"
-	ErrCodeBadRequest  ErrorCode = "BAD_REQUEST"
-	ErrCodeUnauth      ErrorCode = "UNAUTHORIZED"
+	ErrCodeTeamExists        ErrorCode = "TEAM_EXISTS"
+	ErrCodePRExists          ErrorCode = "PR_EXISTS"
+	ErrCodePRMerged          ErrorCode = "PR_MERGED"
+	ErrCodeNotAssigned       ErrorCode = "NOT_ASSIGNED"
+	ErrCodeNoCandidate       ErrorCode = "NO_CANDIDATE"
+	ErrCodeAtCapacity        ErrorCode = "AT_CAPACITY"
+	ErrCodeReviewerNotFound  ErrorCode = "REVIEWER_NOT_FOUND"
+	ErrCodeReviewerInactive  ErrorCode = "REVIEWER_INACTIVE"
+	ErrCodeReviewerIsAuthor  ErrorCode = "REVIEWER_IS_AUTHOR"
+	ErrCodeReviewerExcluded  ErrorCode = "REVIEWER_EXCLUDED"
+	ErrCodeTooManyReviewers  ErrorCode = "TOO_MANY_REVIEWERS"
+	ErrCodeInvalidTransition ErrorCode = "INVALID_TRANSITION"
+	ErrCodePRNotOpen         ErrorCode = "PR_NOT_OPEN"
+	ErrCodeMergeBlocked      ErrorCode = "MERGE_BLOCKED"
+	ErrCodeNoSenior          ErrorCode = "NO_SENIOR_REVIEWER"
+	ErrCodeNotFound          ErrorCode = "NOT_FOUND"
+	ErrCodeInternal          ErrorCode = "INTERNAL_ERROR"
+	ErrCodeBadRequest        ErrorCode = "BAD_REQUEST"
+	ErrCodeUnauth            ErrorCode = "UNAUTHORIZED"
 )
 
 type AppError struct {
 	Code    ErrorCode
 	Message string
+	// Details — список конкретных причин, например невыполненных условий merge
+	Details []string
 }
 
 func (e *AppError) Error() string {
@@ -33,18 +48,69 @@ func NewAppError(code ErrorCode, message string) *AppError {
 }
 
 var (
-	ErrTeamAlreadyExists   = NewAppError(ErrCodeTeamExists, "team_name already exists")
-	ErrPRAlreadyExists     = NewAppError(ErrCodePRExists, "PR id already exists")
-	ErrPRMerged            = NewAppError(ErrCodePRMerged, "cannot reassign on merged PR")
-	ErrReviewerNotAssigned = NewAppError(ErrCodeNotAssigned, "reviewer is not assigned to this PR")
-	ErrNoActiveCandidate   = NewAppError(ErrCodeNoCandidate, "no active replacement candidate in team")
-	ErrTeamNotFound        = NewAppError(ErrCodeNotFound, "team not found")
-	ErrUserNotFound        = NewAppError(ErrCodeNotFound, "user not found")
-	ErrPRNotFound          = NewAppError(ErrCodeNotFound, "PR not found")
-	ErrUnauthorized        = NewAppError(ErrCodeUnauth, "unauthorized")
-	ErrInvalidToken        = NewAppError(ErrCodeUnauth, "invalid token")
+	ErrTeamAlreadyExists         = NewAppError(ErrCodeTeamExists, "team_name already exists")
+	ErrPRAlreadyExists           = NewAppError(ErrCodePRExists, "PR id already exists")
+	ErrPRMerged                  = NewAppError(ErrCodePRMerged, "cannot reassign on merged PR")
+	ErrPRNotOpen                 = NewAppError(ErrCodePRNotOpen, "PR is not open")
+	ErrReviewOnMergedPR          = NewAppError(ErrCodePRMerged, "cannot review merged PR")
+	ErrInvalidReviewState        = NewAppError(ErrCodeBadRequest, "state must be APPROVED, CHANGES_REQUESTED or COMMENTED")
+	ErrReviewerNotAssigned       = NewAppError(ErrCodeNotAssigned, "reviewer is not assigned to this PR")
+	ErrNoActiveCandidate         = NewAppError(ErrCodeNoCandidate, "no active replacement candidate in team")
+	ErrNotEnoughReviewers        = NewAppError(ErrCodeNoCandidate, "not enough active reviewers to satisfy team minimum")
+	ErrInvalidTeamSettings       = NewAppError(ErrCodeBadRequest, "min_reviewers must be between 0 and max_reviewers, max_reviewers must be positive")
+	ErrTeamSettingsNotFound      = NewAppError(ErrCodeNotFound, "team settings not found")
+	ErrReviewersAtCapacity       = NewAppError(ErrCodeAtCapacity, "all candidate reviewers are at capacity")
+	ErrInvalidCapacity           = NewAppError(ErrCodeBadRequest, "max_open_reviews must not be negative")
+	ErrInvalidCodeOwnerScope     = NewAppError(ErrCodeBadRequest, "scope_type must be team or repository")
+	ErrInvalidFallbackTeam       = NewAppError(ErrCodeBadRequest, "fallback team must exist and differ from the team itself")
+	ErrInvalidParentTeam         = NewAppError(ErrCodeBadRequest, "parent team must exist and must not be the team itself or its descendant")
+	ErrInvalidTeamName           = NewAppError(ErrCodeBadRequest, "new_team_name must be non-empty")
+	ErrTeamNotEmpty              = NewAppError(ErrCodeBadRequest, "team must have no members and no sub-teams to be deleted")
+	ErrInvalidMergeTarget        = NewAppError(ErrCodeBadRequest, "target team must differ from the source team and must not be its descendant")
+	ErrInvalidImportFormat       = NewAppError(ErrCodeBadRequest, "format must be csv or yaml")
+	ErrInvalidImportMode         = NewAppError(ErrCodeBadRequest, "mode must be validate or upsert")
+	ErrEmptyImport               = NewAppError(ErrCodeBadRequest, "import contains no rows")
+	ErrInvalidSkill              = NewAppError(ErrCodeBadRequest, "skills must be non-empty")
+	ErrInvalidLabel              = NewAppError(ErrCodeBadRequest, "labels must be non-empty")
+	ErrAuthorChangeOnMergedPR    = NewAppError(ErrCodePRMerged, "cannot change author of merged PR")
+	ErrRequestedReviewerNotFound = NewAppError(ErrCodeReviewerNotFound, "requested reviewer not found")
+	ErrRequestedReviewerInactive = NewAppError(ErrCodeReviewerInactive, "requested reviewer is inactive")
+	ErrRequestedReviewerIsAuthor = NewAppError(ErrCodeReviewerIsAuthor, "author cannot review own PR")
+	ErrRequestedReviewerExcluded = NewAppError(ErrCodeReviewerExcluded, "reviewer is both requested and excluded")
+	ErrTooManyRequestedReviewers = NewAppError(ErrCodeTooManyReviewers, "more reviewers requested than max_reviewers")
+	ErrInvalidPRSize             = NewAppError(ErrCodeBadRequest, "size must be xs, s, m, l or xl, line counts must not be negative")
+	ErrInvalidReviewersBySize    = NewAppError(ErrCodeBadRequest, "reviewers_by_size keys must be xs, s, m, l or xl, values must be positive")
+	ErrInvalidSizeThresholds     = NewAppError(ErrCodeBadRequest, "size_thresholds keys must be xs, s, m or l, values must be positive and increasing")
+	ErrInvalidReviewSLA          = NewAppError(ErrCodeBadRequest, "first_response_hours must not be negative, action must be notify or reassign")
+	ErrInvalidMergePolicy        = NewAppError(ErrCodeBadRequest, "min_approvals must not be negative, required_approval_team must exist")
+	ErrInvalidUserLevel          = NewAppError(ErrCodeBadRequest, "level must be junior, middle, senior or lead")
+	ErrSeniorReviewerRequired    = NewAppError(ErrCodeNoSenior, "team requires a senior reviewer, but none is available")
+	ErrInvalidOutOfOffice        = NewAppError(ErrCodeBadRequest, "ends_at must be after starts_at")
+	ErrOutOfOfficeNotFound       = NewAppError(ErrCodeNotFound, "out of office period not found")
+	ErrTeamNotFound              = NewAppError(ErrCodeNotFound, "team not found")
+	ErrUserNotInTeam             = NewAppError(ErrCodeBadRequest, "user is not a member of the team")
+	ErrAuthorNotInTeam           = NewAppError(ErrCodeBadRequest, "author is not a member of team_name")
+	ErrNoMembersToRemove         = NewAppError(ErrCodeBadRequest, "no team members to remove")
+	ErrUserNotFound              = NewAppError(ErrCodeNotFound, "user not found")
+	ErrPRNotFound                = NewAppError(ErrCodeNotFound, "PR not found")
+	ErrUnauthorized              = NewAppError(ErrCodeUnauth, "unauthorized")
+	ErrInvalidToken              = NewAppError(ErrCodeUnauth, "invalid token")
 )
 
+// NewInvalidTransitionError сообщает о недопустимом переходе между статусами PR
+func NewInvalidTransitionError(from, to PRStatus) *AppError {
+	return NewAppError(ErrCodeInvalidTransition, fmt.Sprintf("cannot move PR from %s to %s", from, to))
+}
+
+// NewMergeBlockedError перечисляет невыполненные условия политики merge
+func NewMergeBlockedError(conditions []string) *AppError {
+	return &AppError{
+		Code:    ErrCodeMergeBlocked,
+		Message: "merge blocked: " + strings.Join(conditions, "; "),
+		Details: conditions,
+	}
+}
+
 func NewDatabaseError(operation string, err error) *AppError {
 	return NewAppError(ErrCodeInternal, fmt.Sprintf("database %s failed: %v", operation, err))
 }
@@ -53,6 +119,7 @@ type ErrorResponse struct {
 	Error struct {
 		Code    ErrorCode `json:"code"`
 		Message string    `json:"message"`
+		Details []string  `json:"details,omitempty"`
 	} `json:"error"`
 }
 
@@ -60,5 +127,6 @@ func NewErrorResponse(err *AppError) ErrorResponse {
 	var resp ErrorResponse
 	resp.Error.Code = err.Code
 	resp.Error.Message = err.Message
+	resp.Error.Details = err.Details
 	return resp
 }
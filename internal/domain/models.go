@@ -5,55 +5,495 @@ import "time"
 type User struct {
 	UserID   string `json:"user_id" gorm:"primaryKey"`
 	Username string `json:"username" gorm:"not null"`
-	TeamName string `json:"team_name" gorm:"index;not null"`
-	IsActive bool   `json:"is_active" gorm:"default:true"`
+	// TeamName — основная команда: из нее по умолчанию подбираются ревьюверы для PR пользователя.
+	// Все команды пользователя хранятся в TeamMembership; пуст у пользователя без команд.
+	TeamName       string    `json:"team_name" gorm:"index;default:null"`
+	IsActive       bool      `json:"is_active" gorm:"default:true"`
+	MaxOpenReviews *int      `json:"max_open_reviews,omitempty"`
+	Skills         []string  `json:"skills,omitempty" gorm:"serializer:json"`
+	Level          UserLevel `json:"level,omitempty" gorm:"type:varchar(10)"`
+}
+
+// UserLevel — уровень пользователя; senior и lead считаются старшими ревьюверами
+type UserLevel string
+
+const (
+	UserLevelJunior UserLevel = "junior"
+	UserLevelMiddle UserLevel = "middle"
+	UserLevelSenior UserLevel = "senior"
+	UserLevelLead   UserLevel = "lead"
+)
+
+func (l UserLevel) Valid() bool {
+	switch l {
+	case "", UserLevelJunior, UserLevelMiddle, UserLevelSenior, UserLevelLead:
+		return true
+	}
+	return false
+}
+
+func (l UserLevel) IsSenior() bool {
+	return l == UserLevelSenior || l == UserLevelLead
 }
 
 type Team struct {
-	TeamName string `json:"team_name" gorm:"primaryKey"`
-	Members  []User `json:"members" gorm:"foreignKey:TeamName;references:TeamName"`
+	TeamName              string `json:"team_name" gorm:"primaryKey"`
+	DefaultMaxOpenReviews *int   `json:"default_max_open_reviews,omitempty"`
+	// ParentTeam строит иерархию (организация → отдел → команда); пуст у корневых команд
+	ParentTeam string `json:"parent_team,omitempty" gorm:"index;default:null"`
+	// Members загружаются через TeamMembership
+	Members []User `json:"members" gorm:"-"`
+}
+
+// TeamMembership — участие пользователя в команде; пользователь может состоять в нескольких командах
+type TeamMembership struct {
+	TeamName string `gorm:"primaryKey"`
+	UserID   string `gorm:"primaryKey;index"`
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	IsActive       bool      `json:"is_active"`
+	MaxOpenReviews *int      `json:"max_open_reviews,omitempty"`
+	Skills         []string  `json:"skills,omitempty"`
+	Level          UserLevel `json:"level,omitempty"`
 }
 
 type TeamResponse struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName              string       `json:"team_name"`
+	DefaultMaxOpenReviews *int         `json:"default_max_open_reviews,omitempty"`
+	ParentTeam            string       `json:"parent_team,omitempty"`
+	Members               []TeamMember `json:"members"`
+	// SubTeams заполняется, когда участники собраны по всему поддереву команды
+	SubTeams []string `json:"sub_teams,omitempty"`
+	// ReassignedPRs — открытые PR, с которых сняты деактивированные запросом участники
+	ReassignedPRs []PRReassignmentSummary `json:"reassigned_prs,omitempty"`
+}
+
+// TeamSummary — строка списка команд
+type TeamSummary struct {
+	TeamName    string `json:"team_name"`
+	ParentTeam  string `json:"parent_team,omitempty"`
+	Depth       int    `json:"depth"`
+	MemberCount int    `json:"member_count"`
 }
 
 type PRStatus string
 
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// prTransitions — допустимые переходы между статусами PR; MERGED конечный
+var prTransitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	for _, allowed := range prTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type SelectionStrategy string
+
+const (
+	SelectionStrategyRandom         SelectionStrategy = "random"
+	SelectionStrategyRoundRobin     SelectionStrategy = "round_robin"
+	SelectionStrategyLeastLoaded    SelectionStrategy = "least_loaded"
+	SelectionStrategyPairingHistory SelectionStrategy = "pairing_history"
+)
+
+const DefaultMaxReviewers = 2
+
+// PRSize — класс размера PR по суммарному числу добавленных и удаленных строк
+type PRSize string
+
+const (
+	PRSizeXS PRSize = "xs"
+	PRSizeS  PRSize = "s"
+	PRSizeM  PRSize = "m"
+	PRSizeL  PRSize = "l"
+	PRSizeXL PRSize = "xl"
+)
+
+func (s PRSize) Valid() bool {
+	switch s {
+	case PRSizeXS, PRSizeS, PRSizeM, PRSizeL, PRSizeXL:
+		return true
+	}
+	return false
+}
+
+// thresholdSizes — классы, для которых задается верхняя граница; все, что больше границы l, — xl
+var thresholdSizes = []PRSize{PRSizeXS, PRSizeS, PRSizeM, PRSizeL}
+
+// DefaultPRSizeThresholds — границы классов по умолчанию: xs < 10, s < 50, m < 250, l < 1000, xl — остальное
+func DefaultPRSizeThresholds() map[PRSize]int {
+	return map[PRSize]int{PRSizeXS: 10, PRSizeS: 50, PRSizeM: 250, PRSizeL: 1000}
+}
+
+// MergeSizeThresholds дополняет границы классов override значениями из base.
+// Второе значение ложно, если ключи или значения недопустимы: ключи — xs, s, m, l,
+// значения положительны и возрастают от xs к l.
+func MergeSizeThresholds(base, override map[PRSize]int) (map[PRSize]int, bool) {
+	merged := DefaultPRSizeThresholds()
+	for size, n := range base {
+		merged[size] = n
+	}
+	for size, n := range override {
+		if _, ok := merged[size]; !ok || n < 1 {
+			return nil, false
+		}
+		merged[size] = n
+	}
+
+	for i := 1; i < len(thresholdSizes); i++ {
+		if merged[thresholdSizes[i]] <= merged[thresholdSizes[i-1]] {
+			return nil, false
+		}
+	}
+
+	return merged, true
+}
+
+// TeamSettings хранит настройки назначения ревьюверов для команды автора PR
+type TeamSettings struct {
+	TeamName      string   `json:"team_name" gorm:"primaryKey"`
+	MinReviewers  int      `json:"min_reviewers" gorm:"not null;default:0"`
+	MaxReviewers  int      `json:"max_reviewers" gorm:"not null;default:2"`
+	FallbackTeams []string `json:"fallback_teams" gorm:"serializer:json"`
+	RequireSenior bool     `json:"require_senior" gorm:"not null;default:false"`
+	// ReviewersBySize переопределяет max_reviewers для PR указанного размера
+	ReviewersBySize map[PRSize]int `json:"reviewers_by_size" gorm:"serializer:json"`
+	// SizeThresholds — верхние границы (не включительно) классов xs, s, m, l по числу строк;
+	// у настроек, сохраненных до их появления, пусто и действуют границы по умолчанию
+	SizeThresholds map[PRSize]int `json:"size_thresholds" gorm:"serializer:json"`
+	MergePolicy    MergePolicy    `json:"merge_policy" gorm:"embedded;embeddedPrefix:merge_"`
+	ReviewSLA      ReviewSLA      `json:"review_sla" gorm:"embedded;embeddedPrefix:sla_"`
+}
+
+type SLAAction string
+
+const (
+	SLAActionNotify   SLAAction = "notify"
+	SLAActionReassign SLAAction = "reassign"
+)
+
+func (a SLAAction) Valid() bool {
+	return a == SLAActionNotify || a == SLAActionReassign
+}
+
+// ReviewSLA — за сколько часов назначенный ревьювер должен отреагировать на PR
+// и что делать при нарушении. Нулевой срок отключает SLA.
+type ReviewSLA struct {
+	FirstResponseHours int       `json:"first_response_hours" gorm:"not null;default:0"`
+	Action             SLAAction `json:"action,omitempty" gorm:"type:varchar(10)"`
+}
+
+func (s ReviewSLA) Enabled() bool {
+	return s.FirstResponseHours > 0
+}
+
+func (s ReviewSLA) Deadline(assignedAt time.Time) time.Time {
+	return assignedAt.Add(time.Duration(s.FirstResponseHours) * time.Hour)
+}
+
+// MergePolicy — условия, которые должны выполняться для merge PR авторов команды.
+// Нулевое значение ничего не требует.
+type MergePolicy struct {
+	MinApprovals         int    `json:"min_approvals" gorm:"not null;default:0"`
+	NoChangesRequested   bool   `json:"no_changes_requested" gorm:"not null;default:false"`
+	RequiredApprovalTeam string `json:"required_approval_team,omitempty"`
+}
+
+func DefaultTeamSettings(teamName string) *TeamSettings {
+	return &TeamSettings{
+		TeamName:        teamName,
+		MinReviewers:    0,
+		MaxReviewers:    DefaultMaxReviewers,
+		FallbackTeams:   []string{},
+		ReviewersBySize: map[PRSize]int{},
+		SizeThresholds:  DefaultPRSizeThresholds(),
+	}
+}
+
+// SizeForLines возвращает класс размера PR с lines измененными строками по границам команды
+func (s *TeamSettings) SizeForLines(lines int) PRSize {
+	thresholds := DefaultPRSizeThresholds()
+	for size, n := range s.SizeThresholds {
+		thresholds[size] = n
+	}
+
+	for _, size := range thresholdSizes {
+		if lines < thresholds[size] {
+			return size
+		}
+	}
+	return PRSizeXL
+}
+
+// ForSize возвращает копию настроек с числом ревьюверов для PR заданного размера.
+// Минимум не может превышать это число, иначе маленький PR нельзя было бы создать.
+func (s *TeamSettings) ForSize(size PRSize) *TeamSettings {
+	effective := *s
+	if n, ok := s.ReviewersBySize[size]; ok {
+		effective.MaxReviewers = n
+		if effective.MinReviewers > n {
+			effective.MinReviewers = n
+		}
+	}
+	return &effective
+}
+
+// CapacityPolicy определяет поведение, когда все кандидаты достигли лимита открытых ревью
+type CapacityPolicy string
+
+const (
+	CapacityPolicyFewer  CapacityPolicy = "fewer"
+	CapacityPolicyReject CapacityPolicy = "reject"
+	CapacityPolicyIgnore CapacityPolicy = "ignore"
 )
 
+func (p CapacityPolicy) Valid() bool {
+	switch p {
+	case CapacityPolicyFewer, CapacityPolicyReject, CapacityPolicyIgnore:
+		return true
+	}
+	return false
+}
+
 type PullRequest struct {
-	PullRequestID   string     `json:"pull_request_id" gorm:"primaryKey"`
-	PullRequestName string     `json:"pull_request_name" gorm:"not null"`
-	AuthorID        string     `json:"author_id" gorm:"not null;index"`
-	Status          PRStatus   `json:"status" gorm:"type:varchar(10);default:'OPEN'"`
-	CreatedAt       *time.Time `json:"createdAt,omitempty" gorm:"autoCreateTime"`
-	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID   string `json:"pull_request_id" gorm:"primaryKey"`
+	PullRequestName string `json:"pull_request_name" gorm:"not null"`
+	AuthorID        string `json:"author_id" gorm:"not null;index"`
+	// TeamName — команда, из которой подбираются ревьюверы и берутся настройки; пуст у старых PR
+	TeamName     string   `json:"team_name,omitempty" gorm:"index"`
+	Status       PRStatus `json:"status" gorm:"type:varchar(10);default:'OPEN'"`
+	LinesAdded   int      `json:"lines_added,omitempty" gorm:"not null;default:0"`
+	LinesDeleted int      `json:"lines_deleted,omitempty" gorm:"not null;default:0"`
+	Size         PRSize   `json:"size,omitempty" gorm:"type:varchar(5);index"`
+	Labels       []string `json:"labels,omitempty" gorm:"serializer:json"`
+	// DraftInput хранит параметры назначения черновика до /pullRequest/ready
+	DraftInput DraftInput `json:"-" gorm:"serializer:json"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty" gorm:"autoCreateTime"`
+	MergedAt   *time.Time `json:"mergedAt,omitempty"`
+}
+
+// DraftInput — параметры назначения ревьюверов, переданные при создании черновика
+type DraftInput struct {
+	Repository         string   `json:"repository,omitempty"`
+	ChangedFiles       []string `json:"changed_files,omitempty"`
+	RequiredTags       []string `json:"required_tags,omitempty"`
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	ExcludedReviewers  []string `json:"excluded_reviewers,omitempty"`
+}
+
+// ReviewPairing — факт назначения ревьювера на PR автора по истории назначений
+type ReviewPairing struct {
+	ReviewerID string
+	PairedAt   time.Time
+}
+
+type PREventType string
+
+const (
+	PREventCreated          PREventType = "created"
+	PREventReviewerAssigned PREventType = "reviewer_assigned"
+	PREventReviewerRemoved  PREventType = "reviewer_removed"
+	PREventReassigned       PREventType = "reassigned"
+	PREventStateChanged     PREventType = "state_changed"
+	PREventMerged           PREventType = "merged"
+	PREventUpdated          PREventType = "updated"
+	PREventSLAEscalated     PREventType = "sla_escalated"
+)
+
+// Причины снятия и замены ревьюверов в истории PR
+const (
+	PREventReasonManual       = "manual"
+	PREventReasonDeactivation = "deactivation"
+	PREventReasonOutOfOffice  = "out_of_office"
+	PREventReasonSLA          = "sla"
+	PREventReasonInactive     = "inactive"
+	PREventReasonAuthorChange = "author_changed"
+	PREventReasonTeamChange   = "team_change"
+)
+
+// PREvent — запись истории PR, записи только добавляются
+type PREvent struct {
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	PullRequestID string      `json:"pull_request_id" gorm:"index;not null"`
+	Type          PREventType `json:"type" gorm:"type:varchar(30);not null"`
+	ReviewerID    string      `json:"reviewer_id,omitempty"`
+	NewReviewerID string      `json:"new_reviewer_id,omitempty"`
+	Reason        string      `json:"reason,omitempty"`
+	FromStatus    PRStatus    `json:"from_status,omitempty" gorm:"type:varchar(10)"`
+	ToStatus      PRStatus    `json:"to_status,omitempty" gorm:"type:varchar(10)"`
+	// Fields — измененные поля PR для события updated; при смене автора заполняются и авторы
+	Fields       []string `json:"fields,omitempty" gorm:"serializer:json"`
+	FromAuthorID string   `json:"from_author_id,omitempty"`
+	ToAuthorID   string   `json:"to_author_id,omitempty"`
+	// Forced и Conditions заполняются для merge в обход политики
+	Forced     bool      `json:"forced,omitempty"`
+	Conditions []string  `json:"conditions,omitempty" gorm:"serializer:json"`
+	CreatedAt  time.Time `json:"created_at" gorm:"not null"`
+}
+
+type CodeOwnerScope string
+
+const (
+	CodeOwnerScopeTeam       CodeOwnerScope = "team"
+	CodeOwnerScopeRepository CodeOwnerScope = "repository"
+)
+
+// CodeOwnerRule — строка файла владения в стиле CODEOWNERS.
+// При совпадении нескольких правил действует последнее по Position.
+type CodeOwnerRule struct {
+	ID        uint           `json:"-" gorm:"primaryKey"`
+	ScopeType CodeOwnerScope `json:"scope_type" gorm:"type:varchar(20);index:idx_code_owner_rules_scope;not null"`
+	ScopeName string         `json:"scope_name" gorm:"index:idx_code_owner_rules_scope;not null"`
+	Position  int            `json:"position" gorm:"not null"`
+	Pattern   string         `json:"pattern" gorm:"not null"`
+	Owners    []string       `json:"owners" gorm:"serializer:json"`
+}
+
+// OutOfOffice — период отсутствия пользователя, в который ему не назначаются ревью.
+// Период полуоткрытый: [StartsAt, EndsAt).
+type OutOfOffice struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	UserID            string    `json:"user_id" gorm:"index;not null"`
+	StartsAt          time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt            time.Time `json:"ends_at" gorm:"not null"`
+	Reason            string    `json:"reason,omitempty"`
+	ReassignReviews   bool      `json:"reassign_reviews"`
+	ReviewsReassigned bool      `json:"reviews_reassigned"`
+}
+
+type AssignmentSource string
+
+const (
+	AssignmentSourceRequested   AssignmentSource = "requested"
+	AssignmentSourceTeam        AssignmentSource = "team"
+	AssignmentSourceCodeOwner   AssignmentSource = "code_owner"
+	AssignmentSourceSkillTag    AssignmentSource = "skill_tag"
+	AssignmentSourceSenior      AssignmentSource = "senior"
+	AssignmentSourceFallback    AssignmentSource = "fallback"
+	AssignmentSourceParentTeam  AssignmentSource = "parent_team"
+	AssignmentSourceReplacement AssignmentSource = "replacement"
+)
+
+type ExclusionReason string
+
+const (
+	ExclusionAuthor          ExclusionReason = "author"
+	ExclusionInactive        ExclusionReason = "inactive"
+	ExclusionAtCapacity      ExclusionReason = "at_capacity"
+	ExclusionAlreadyAssigned ExclusionReason = "already_assigned"
+	ExclusionOutOfOffice     ExclusionReason = "out_of_office"
+	ExclusionNotEligible     ExclusionReason = "not_eligible"
+	ExclusionExcluded        ExclusionReason = "excluded"
+)
+
+type ExcludedCandidate struct {
+	UserID string          `json:"user_id"`
+	Reason ExclusionReason `json:"reason"`
+}
+
+// AssignmentRecord объясняет, почему ревьювер был назначен на PR
+type AssignmentRecord struct {
+	ID                uint                `json:"-" gorm:"primaryKey"`
+	PullRequestID     string              `json:"pull_request_id" gorm:"index;not null"`
+	ReviewerID        string              `json:"reviewer_id" gorm:"not null"`
+	Source            AssignmentSource    `json:"source" gorm:"type:varchar(20);not null"`
+	Strategy          SelectionStrategy   `json:"strategy,omitempty" gorm:"type:varchar(20)"`
+	CandidatePoolSize int                 `json:"candidate_pool_size"`
+	Excluded          []ExcludedCandidate `json:"excluded" gorm:"serializer:json"`
+	AssignedAt        time.Time           `json:"assigned_at" gorm:"not null"`
+}
+
+type ReviewState string
+
+const (
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCommented        ReviewState = "COMMENTED"
+)
+
+// Submittable сообщает, может ли ревьювер отправить такое решение (PENDING выставляется только при назначении)
+func (s ReviewState) Submittable() bool {
+	switch s {
+	case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateCommented:
+		return true
+	}
+	return false
 }
 
 type PRReviewer struct {
-	PullRequestID string `gorm:"primaryKey"`
-	ReviewerID    string `gorm:"primaryKey"`
+	PullRequestID string      `gorm:"primaryKey"`
+	ReviewerID    string      `gorm:"primaryKey"`
+	State         ReviewState `gorm:"type:varchar(20);not null;default:'PENDING'"`
+	ReviewedAt    *time.Time
+	AssignedAt    time.Time `gorm:"not null;default:now()"`
+	// EscalatedAt — когда по назначению сработало уведомление о нарушении SLA
+	EscalatedAt *time.Time
+}
+
+// PendingReview — назначение на открытый PR, по которому ревьювер еще не отреагировал и эскалации не было
+type PendingReview struct {
+	PullRequestID string
+	AuthorID      string
+	TeamName      string
+	ReviewerID    string
+	AssignedAt    time.Time
+}
+
+// ReviewerStatus — ревьювер PR и его последнее решение
+type ReviewerStatus struct {
+	UserID     string      `json:"user_id"`
+	State      ReviewState `json:"state"`
+	ReviewedAt *time.Time  `json:"reviewed_at,omitempty"`
+}
+
+// PendingReviewers возвращает только что назначенных ревьюверов без решения
+func PendingReviewers(reviewerIDs []string) []ReviewerStatus {
+	result := make([]ReviewerStatus, len(reviewerIDs))
+	for i, id := range reviewerIDs {
+		result[i] = ReviewerStatus{UserID: id, State: ReviewStatePending}
+	}
+	return result
 }
 
 type PullRequestResponse struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            PRStatus   `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID     string           `json:"pull_request_id"`
+	PullRequestName   string           `json:"pull_request_name"`
+	AuthorID          string           `json:"author_id"`
+	TeamName          string           `json:"team_name,omitempty"`
+	Status            PRStatus         `json:"status"`
+	AssignedReviewers []ReviewerStatus `json:"assigned_reviewers"`
+	FallbackReviewers []string         `json:"fallback_reviewers,omitempty"`
+	UncoveredTags     []string         `json:"uncovered_tags,omitempty"`
+	Size              PRSize           `json:"size,omitempty"`
+	Labels            []string         `json:"labels,omitempty"`
+	CreatedAt         *time.Time       `json:"createdAt,omitempty"`
+	MergedAt          *time.Time       `json:"mergedAt,omitempty"`
+}
+
+func (r *PullRequestResponse) ReviewerIDs() []string {
+	ids := make([]string, len(r.AssignedReviewers))
+	for i, reviewer := range r.AssignedReviewers {
+		ids[i] = reviewer.UserID
+	}
+	return ids
 }
 
 type PullRequestShort struct {
@@ -61,11 +501,116 @@ type PullRequestShort struct {
 	PullRequestName string   `json:"pull_request_name"`
 	AuthorID        string   `json:"author_id"`
 	Status          PRStatus `json:"status"`
+	Labels          []string `json:"labels,omitempty"`
 }
 
 type CreateTeamRequest struct {
-	TeamName string       `json:"team_name" binding:"required"`
-	Members  []TeamMember `json:"members" binding:"required"`
+	TeamName              string       `json:"team_name" binding:"required"`
+	DefaultMaxOpenReviews *int         `json:"default_max_open_reviews,omitempty"`
+	ParentTeam            string       `json:"parent_team,omitempty"`
+	Members               []TeamMember `json:"members" binding:"required"`
+}
+
+// SetParentTeamRequest меняет родительскую команду; пустой ParentTeam делает команду корневой
+type SetParentTeamRequest struct {
+	TeamName   string `json:"team_name" binding:"required"`
+	ParentTeam string `json:"parent_team"`
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name" binding:"required"`
+	NewTeamName string `json:"new_team_name" binding:"required"`
+}
+
+type DeleteTeamRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+}
+
+// MergeTeamsRequest переносит участников, PR и дочерние команды SourceTeam в TargetTeam и удаляет SourceTeam
+type MergeTeamsRequest struct {
+	SourceTeam string `json:"source_team" binding:"required"`
+	TargetTeam string `json:"target_team" binding:"required"`
+}
+
+type MergeTeamsResponse struct {
+	SourceTeam   string        `json:"source_team"`
+	Team         *TeamResponse `json:"team"`
+	MovedMembers []string      `json:"moved_members"`
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "csv"
+	ImportFormatYAML ImportFormat = "yaml"
+)
+
+// ImportMode: validate только проверяет строки и строит отчет, upsert еще и применяет их
+type ImportMode string
+
+const (
+	ImportModeValidate ImportMode = "validate"
+	ImportModeUpsert   ImportMode = "upsert"
+)
+
+func (m ImportMode) Valid() bool {
+	return m == ImportModeValidate || m == ImportModeUpsert
+}
+
+type ImportAction string
+
+const (
+	ImportActionCreated   ImportAction = "created"
+	ImportActionUpdated   ImportAction = "updated"
+	ImportActionUnchanged ImportAction = "unchanged"
+	ImportActionRejected  ImportAction = "rejected"
+)
+
+type ImportTeamsRequest struct {
+	Format  ImportFormat
+	Mode    ImportMode
+	Content string
+}
+
+// ImportRowResult — результат строки импорта. Action относится к участнику,
+// а для строки без user_id — к самой команде; TeamAction заполняется, когда строка участника
+// создает команду или меняет ее родителя.
+type ImportRowResult struct {
+	Row        int          `json:"row"`
+	TeamName   string       `json:"team_name"`
+	UserID     string       `json:"user_id,omitempty"`
+	Action     ImportAction `json:"action"`
+	TeamAction ImportAction `json:"team_action,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+type ImportSummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Rejected  int `json:"rejected"`
+}
+
+type ImportTeamsResponse struct {
+	Mode ImportMode `json:"mode"`
+	// Applied — изменения сохранены; при отклоненных строках не применяется ничего
+	Applied bool              `json:"applied"`
+	Summary ImportSummary     `json:"summary"`
+	Rows    []ImportRowResult `json:"rows"`
+	// ReassignedPRs — открытые PR, с которых сняты деактивированные импортом пользователи
+	ReassignedPRs []PRReassignmentSummary `json:"reassigned_prs,omitempty"`
+}
+
+type SetTeamSettingsRequest struct {
+	TeamName        string         `json:"team_name" binding:"required"`
+	MinReviewers    *int           `json:"min_reviewers,omitempty"`
+	MaxReviewers    *int           `json:"max_reviewers,omitempty"`
+	FallbackTeams   []string       `json:"fallback_teams,omitempty"`
+	RequireSenior   *bool          `json:"require_senior,omitempty"`
+	ReviewersBySize map[PRSize]int `json:"reviewers_by_size,omitempty"`
+	SizeThresholds  map[PRSize]int `json:"size_thresholds,omitempty"`
+	MergePolicy     *MergePolicy   `json:"merge_policy,omitempty"`
+	ReviewSLA       *ReviewSLA     `json:"review_sla,omitempty"`
 }
 
 type SetIsActiveRequest struct {
@@ -73,14 +618,118 @@ type SetIsActiveRequest struct {
 	IsActive bool   `json:"is_active"`
 }
 
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id" binding:"required"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type SetUserLevelRequest struct {
+	UserID string    `json:"user_id" binding:"required"`
+	Level  UserLevel `json:"level"`
+}
+
+type AddOutOfOfficeRequest struct {
+	UserID          string    `json:"user_id" binding:"required"`
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	EndsAt          time.Time `json:"ends_at" binding:"required"`
+	Reason          string    `json:"reason,omitempty"`
+	ReassignReviews bool      `json:"reassign_reviews"`
+}
+
+type RemoveOutOfOfficeRequest struct {
+	ID uint `json:"id" binding:"required"`
+}
+
+type OutOfOfficeResponse struct {
+	UserID  string        `json:"user_id"`
+	Periods []OutOfOffice `json:"periods"`
+}
+
+type PRHistoryResponse struct {
+	PullRequestID string    `json:"pull_request_id"`
+	Events        []PREvent `json:"events"`
+}
+
+type AssignmentTraceResponse struct {
+	PullRequestID string             `json:"pull_request_id"`
+	Records       []AssignmentRecord `json:"records"`
+}
+
+type UserSkillsRequest struct {
+	UserID string   `json:"user_id" binding:"required"`
+	Skills []string `json:"skills"`
+}
+
 type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id" binding:"required"`
 	PullRequestName string `json:"pull_request_name" binding:"required"`
 	AuthorID        string `json:"author_id" binding:"required"`
+	// TeamName выбирает команду PR, если автор состоит в нескольких; по умолчанию — основная команда автора
+	TeamName     string   `json:"team_name,omitempty"`
+	Repository   string   `json:"repository,omitempty"`
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	RequiredTags []string `json:"required_tags,omitempty"`
+	// Запрошенные ревьюверы назначаются первыми, исключенные не назначаются никогда
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	ExcludedReviewers  []string `json:"excluded_reviewers,omitempty"`
+	// Размер PR: явный size важнее числа строк
+	LinesAdded   int    `json:"lines_added,omitempty"`
+	LinesDeleted int    `json:"lines_deleted,omitempty"`
+	Size         PRSize `json:"size,omitempty"`
+	// Draft создает PR в статусе DRAFT без ревьюверов
+	Draft  bool     `json:"draft,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// UpdatePRRequest меняет метаданные PR. Незаданные поля не меняются, пустой список labels снимает все метки.
+type UpdatePRRequest struct {
+	PullRequestID   string   `json:"pull_request_id" binding:"required"`
+	PullRequestName string   `json:"pull_request_name,omitempty"`
+	AuthorID        string   `json:"author_id,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+}
+
+// MarkReadyRequest переводит DRAFT в OPEN; поля назначения те же, что при создании PR
+// MarkReadyRequest отмечает черновик готовым. Незаданные поля берутся из запроса на создание черновика.
+type MarkReadyRequest struct {
+	PullRequestID      string   `json:"pull_request_id" binding:"required"`
+	Repository         string   `json:"repository,omitempty"`
+	ChangedFiles       []string `json:"changed_files,omitempty"`
+	RequiredTags       []string `json:"required_tags,omitempty"`
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	ExcludedReviewers  []string `json:"excluded_reviewers,omitempty"`
+}
+
+type ClosePRRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+}
+
+type ReopenPRRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string      `json:"pull_request_id" binding:"required"`
+	ReviewerID    string      `json:"reviewer_id" binding:"required"`
+	State         ReviewState `json:"state" binding:"required"`
+}
+
+type UploadCodeOwnersRequest struct {
+	ScopeType CodeOwnerScope `json:"scope_type" binding:"required"`
+	ScopeName string         `json:"scope_name" binding:"required"`
+	Content   string         `json:"content" binding:"required"`
+}
+
+type CodeOwnersResponse struct {
+	ScopeType CodeOwnerScope  `json:"scope_type"`
+	ScopeName string          `json:"scope_name"`
+	Rules     []CodeOwnerRule `json:"rules"`
 }
 
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
+	// Force позволяет администратору выполнить merge в обход политики; это фиксируется в истории PR
+	Force bool `json:"force,omitempty"`
 }
 
 type ReassignRequest struct {
@@ -108,10 +757,43 @@ type DeactivateTeamUsersResponse struct {
 	ReassignedPRs    []PRReassignmentSummary `json:"reassigned_prs"`
 }
 
+type AddTeamMembersRequest struct {
+	TeamName string       `json:"team_name" binding:"required"`
+	Members  []TeamMember `json:"members" binding:"required"`
+}
+
+type RemoveTeamMembersRequest struct {
+	TeamName string   `json:"team_name" binding:"required"`
+	UserIDs  []string `json:"user_ids" binding:"required"`
+}
+
+type RemoveTeamMembersResponse struct {
+	TeamName      string                  `json:"team_name"`
+	RemovedUsers  []string                `json:"removed_users"`
+	ReassignedPRs []PRReassignmentSummary `json:"reassigned_prs"`
+}
+
+// MoveUserTeamRequest переводит пользователя из FromTeam (по умолчанию основной) в TeamName
+type MoveUserTeamRequest struct {
+	UserID   string `json:"user_id" binding:"required"`
+	FromTeam string `json:"from_team,omitempty"`
+	TeamName string `json:"team_name" binding:"required"`
+}
+
+type MoveUserTeamResponse struct {
+	UserID        string                  `json:"user_id"`
+	FromTeam      string                  `json:"from_team"`
+	TeamName      string                  `json:"team_name"`
+	ReassignedPRs []PRReassignmentSummary `json:"reassigned_prs"`
+}
+
 type PRReassignmentSummary struct {
-	PullRequestID string   `json:"pull_request_id"`
-	OldReviewers  []string `json:"old_reviewers"`
-	NewReviewers  []string `json:"new_reviewers"`
+	PullRequestID     string   `json:"pull_request_id"`
+	OldReviewers      []string `json:"old_reviewers"`
+	NewReviewers      []string `json:"new_reviewers"`
+	FallbackReviewers []string `json:"fallback_reviewers,omitempty"`
+	// SeniorMissing — у PR не осталось старшего ревьювера, хотя команда автора этого требует
+	SeniorMissing bool `json:"senior_missing,omitempty"`
 }
 
 type PRReassignment struct {
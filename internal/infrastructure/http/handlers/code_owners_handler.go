@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/logger"
+	"pr-reviewer/internal/usecase"
+)
+
+type CodeOwnersHandler struct {
+	service *usecase.CodeOwnersService
+	logger  logger.Logger
+}
+
+func NewCodeOwnersHandler(service *usecase.CodeOwnersService, logger logger.Logger) *CodeOwnersHandler {
+	return &CodeOwnersHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// POST /codeOwners/upload
+func (h *CodeOwnersHandler) UploadCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var req domain.UploadCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Upload code owners request received", "scope_type", req.ScopeType, "scope_name", req.ScopeName)
+
+	result, err := h.service.UploadCodeOwners(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error uploading code owners", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// GET /codeOwners/get
+func (h *CodeOwnersHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	scopeType := domain.CodeOwnerScope(r.URL.Query().Get("scope_type"))
+	scopeName := r.URL.Query().Get("scope_name")
+	if scopeType == "" || scopeName == "" {
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "scope_type and scope_name are required"))
+		return
+	}
+
+	h.logger.Debug("Get code owners request received", "scope_type", scopeType, "scope_name", scopeName)
+
+	result, err := h.service.GetCodeOwners(r.Context(), scopeType, scopeName)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error getting code owners", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage"
 	"pr-reviewer/internal/infrastructure/storage/memory"
 	"pr-reviewer/internal/usecase"
 )
@@ -33,6 +35,15 @@ func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(
 	return fn(ctx)
 }
 
+func newTestAssigner(t *testing.T, repo storage.Repository, selectors *usecase.ReviewerSelectors, capacityPolicy domain.CapacityPolicy) *usecase.ReviewerAssigner {
+	t.Helper()
+	assigner, err := usecase.NewReviewerAssigner(repo, selectors, capacityPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return assigner
+}
+
 func TestTeamHandler_CreateTeam(t *testing.T) {
 	repo := memory.NewMemoryRepository()
 	mockLogger := new(MockLogger)
@@ -42,7 +53,7 @@ func TestTeamHandler_CreateTeam(t *testing.T) {
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
-	service := usecase.NewTeamService(repo, mockTx, mockLogger)
+	service := usecase.NewTeamService(repo, mockTx, newTestAssigner(t, repo, usecase.NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 	handler := NewTeamHandler(service, mockLogger)
 
 	reqBody := domain.CreateTeamRequest{
@@ -81,7 +92,7 @@ func TestTeamHandler_CreateTeam_AlreadyExists(t *testing.T) {
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
-	service := usecase.NewTeamService(repo, mockTx, mockLogger)
+	service := usecase.NewTeamService(repo, mockTx, newTestAssigner(t, repo, usecase.NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 	handler := NewTeamHandler(service, mockLogger)
 
 	reqBody := domain.CreateTeamRequest{
@@ -115,7 +126,7 @@ func TestTeamHandler_GetTeam(t *testing.T) {
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
-	service := usecase.NewTeamService(repo, mockTx, mockLogger)
+	service := usecase.NewTeamService(repo, mockTx, newTestAssigner(t, repo, usecase.NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 	handler := NewTeamHandler(service, mockLogger)
 
 	// Создаем команду
@@ -152,7 +163,7 @@ func TestTeamHandler_GetTeam_NotFound(t *testing.T) {
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
-	service := usecase.NewTeamService(repo, mockTx, mockLogger)
+	service := usecase.NewTeamService(repo, mockTx, newTestAssigner(t, repo, usecase.NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 	handler := NewTeamHandler(service, mockLogger)
 
 	// Пытаемся получить несуществующую команду
@@ -163,6 +174,75 @@ func TestTeamHandler_GetTeam_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestTeamHandler_Import(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+
+	service := usecase.NewTeamService(repo, mockTx, newTestAssigner(t, repo, usecase.NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+	handler := NewTeamHandler(service, mockLogger)
+
+	newRequest := func(filename, content string, fields map[string]string) *http.Request {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for name, value := range fields {
+			_ = writer.WriteField(name, value)
+		}
+		if filename != "" {
+			part, _ := writer.CreateFormFile("file", filename)
+			_, _ = part.Write([]byte(content))
+		}
+		_ = writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/import", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	content := "team_name,user_id,username\nbackend,u1,Alice\n"
+
+	t.Run("validates by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Import(w, newRequest("teams.csv", content, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.ImportTeamsResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, domain.ImportModeValidate, response.Mode)
+		assert.False(t, response.Applied)
+		assert.Equal(t, 1, response.Summary.Created)
+
+		_, err := repo.GetTeam(context.Background(), "backend")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("upserts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Import(w, newRequest("teams.txt", content, map[string]string{"mode": "upsert", "format": "csv"}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		team, err := repo.GetTeam(context.Background(), "backend")
+		assert.NoError(t, err)
+		assert.Len(t, team.Members, 1)
+	})
+
+	t.Run("rejects unknown format and missing file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.Import(w, newRequest("teams.txt", content, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		handler.Import(w, newRequest("", "", map[string]string{"mode": "upsert"}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestRespondJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	data := map[string]string{"message": "success"}
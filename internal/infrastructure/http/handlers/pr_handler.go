@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/infrastructure/logger"
@@ -38,7 +39,7 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 		if appErr, ok := err.(*domain.AppError); ok {
 			statusCode := http.StatusBadRequest
 			switch appErr.Code {
-			case domain.ErrCodePRExists:
+			case domain.ErrCodePRExists, domain.ErrCodeAtCapacity, domain.ErrCodeNoCandidate, domain.ErrCodeNoSenior:
 				statusCode = http.StatusConflict
 			case domain.ErrCodeNotFound:
 				statusCode = http.StatusNotFound
@@ -56,6 +57,177 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// POST /pullRequest/preview
+func (h *PRHandler) PreviewPR(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreatePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Preview PR request received", "name", req.PullRequestName, "author_id", req.AuthorID)
+
+	pr, err := h.service.PreviewPR(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			switch appErr.Code {
+			case domain.ErrCodePRExists, domain.ErrCodeAtCapacity, domain.ErrCodeNoCandidate, domain.ErrCodeNoSenior:
+				statusCode = http.StatusConflict
+			case domain.ErrCodeNotFound:
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error previewing PR", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":      pr,
+		"dry_run": true,
+	})
+}
+
+// POST /pullRequest/ready
+func (h *PRHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	var req domain.MarkReadyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Mark PR ready request received", "pr_id", req.PullRequestID)
+
+	pr, err := h.service.MarkReady(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			switch appErr.Code {
+			case domain.ErrCodeInvalidTransition, domain.ErrCodeAtCapacity, domain.ErrCodeNoCandidate, domain.ErrCodeNoSenior:
+				statusCode = http.StatusConflict
+			case domain.ErrCodeNotFound:
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error marking ready PR", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// POST /pullRequest/close
+func (h *PRHandler) ClosePR(w http.ResponseWriter, r *http.Request) {
+	var req domain.ClosePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Close PR request received", "pr_id", req.PullRequestID)
+
+	pr, err := h.service.ClosePR(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			switch appErr.Code {
+			case domain.ErrCodeInvalidTransition:
+				statusCode = http.StatusConflict
+			case domain.ErrCodeNotFound:
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error closing PR", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// POST /pullRequest/reopen
+func (h *PRHandler) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	var req domain.ReopenPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Reopen PR request received", "pr_id", req.PullRequestID)
+
+	pr, err := h.service.ReopenPR(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			switch appErr.Code {
+			case domain.ErrCodeInvalidTransition:
+				statusCode = http.StatusConflict
+			case domain.ErrCodeNotFound:
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error reopening PR", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// POST /pullRequest/update
+func (h *PRHandler) UpdatePR(w http.ResponseWriter, r *http.Request) {
+	var req domain.UpdatePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Update PR request received", "pr_id", req.PullRequestID, "author_id", req.AuthorID)
+
+	pr, err := h.service.UpdatePR(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			switch appErr.Code {
+			case domain.ErrCodePRMerged:
+				statusCode = http.StatusConflict
+			case domain.ErrCodeNotFound:
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error updating PR", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
 // POST /pullRequest/merge
 func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	var req domain.MergePRRequest
@@ -67,12 +239,15 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Debug("Merge PR request received", "pr_id", req.PullRequestID)
 
-	pr, err := h.service.MergePR(r.Context(), req.PullRequestID)
+	pr, err := h.service.MergePR(r.Context(), req)
 	if err != nil {
 		if appErr, ok := err.(*domain.AppError); ok {
 			statusCode := http.StatusNotFound
-			if appErr.Code == domain.ErrCodeNotFound {
+			switch appErr.Code {
+			case domain.ErrCodeNotFound:
 				statusCode = http.StatusNotFound
+			case domain.ErrCodeMergeBlocked, domain.ErrCodeInvalidTransition:
+				statusCode = http.StatusConflict
 			}
 			respondError(w, statusCode, appErr)
 			return
@@ -89,6 +264,40 @@ func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// POST /pullRequest/review
+func (h *PRHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req domain.SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Submit review request received", "pr_id", req.PullRequestID, "reviewer_id", req.ReviewerID)
+
+	pr, err := h.service.SubmitReview(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			switch appErr.Code {
+			case domain.ErrCodeNotFound:
+				statusCode = http.StatusNotFound
+			case domain.ErrCodePRMerged, domain.ErrCodePRNotOpen, domain.ErrCodeNotAssigned:
+				statusCode = http.StatusConflict
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error submitting review", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
 // POST /pullRequest/reassign
 func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	var req domain.ReassignRequest
@@ -98,11 +307,27 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "dry_run must be a boolean"))
+			return
+		}
+		dryRun = parsed
+	}
+
 	h.logger.Debug("Reassign reviewer request received",
 		"pr_id", req.PullRequestID,
-		"old_user_id", req.OldUserID)
+		"old_user_id", req.OldUserID,
+		"dry_run", dryRun)
+
+	reassign := h.service.ReassignReviewer
+	if dryRun {
+		reassign = h.service.PreviewReassign
+	}
 
-	response, err := h.service.ReassignReviewer(r.Context(), req)
+	response, err := reassign(r.Context(), req)
 	if err != nil {
 		h.logger.Warn("Reassign reviewer failed",
 			"pr_id", req.PullRequestID,
@@ -114,7 +339,7 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 			switch appErr.Code {
 			case domain.ErrCodeNotFound:
 				statusCode = http.StatusNotFound
-			case domain.ErrCodePRMerged, domain.ErrCodeNotAssigned, domain.ErrCodeNoCandidate:
+			case domain.ErrCodePRMerged, domain.ErrCodePRNotOpen, domain.ErrCodeNotAssigned, domain.ErrCodeNoCandidate, domain.ErrCodeAtCapacity, domain.ErrCodeNoSenior:
 				statusCode = http.StatusConflict
 			}
 			respondError(w, statusCode, appErr)
@@ -132,3 +357,51 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, response)
 }
+
+// GET /pullRequest/history
+func (h *PRHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "pull_request_id is required"))
+		return
+	}
+
+	h.logger.Debug("Get PR history request received", "pr_id", prID)
+
+	history, err := h.service.GetHistory(r.Context(), prID)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			respondError(w, http.StatusNotFound, appErr)
+			return
+		}
+		h.logger.Error("Internal error getting PR history", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
+
+// GET /pullRequest/assignmentTrace
+func (h *PRHandler) GetAssignmentTrace(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "pull_request_id is required"))
+		return
+	}
+
+	h.logger.Debug("Get assignment trace request received", "pr_id", prID)
+
+	trace, err := h.service.GetAssignmentTrace(r.Context(), prID)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			respondError(w, http.StatusNotFound, appErr)
+			return
+		}
+		h.logger.Error("Internal error getting assignment trace", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, trace)
+}
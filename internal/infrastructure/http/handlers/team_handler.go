@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
+	"path/filepath"
+	"strings"
 
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/infrastructure/logger"
@@ -61,9 +64,16 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Debug("Get team request received", "team_name", teamName)
+	subtree := r.URL.Query().Get("subtree") == "true"
+	h.logger.Debug("Get team request received", "team_name", teamName, "subtree", subtree)
 
-	team, err := h.service.GetTeam(r.Context(), teamName)
+	var team *domain.TeamResponse
+	var err error
+	if subtree {
+		team, err = h.service.GetTeamTree(r.Context(), teamName)
+	} else {
+		team, err = h.service.GetTeam(r.Context(), teamName)
+	}
 	if err != nil {
 		if appErr, ok := err.(*domain.AppError); ok {
 			statusCode := http.StatusNotFound
@@ -81,6 +91,282 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, team)
 }
 
+// GET /team/list
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	root := r.URL.Query().Get("root")
+
+	h.logger.Debug("List teams request received", "root", root)
+
+	teams, err := h.service.ListTeams(r.Context(), root)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error listing teams", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"teams": teams,
+	})
+}
+
+// POST /team/setParent
+func (h *TeamHandler) SetParentTeam(w http.ResponseWriter, r *http.Request) {
+	var req domain.SetParentTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Set parent team request received", "team_name", req.TeamName, "parent_team", req.ParentTeam)
+
+	team, err := h.service.SetParentTeam(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error setting parent team", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team": team,
+	})
+}
+
+// POST /team/rename
+func (h *TeamHandler) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req domain.RenameTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Rename team request received", "team_name", req.TeamName, "new_team_name", req.NewTeamName)
+
+	team, err := h.service.RenameTeam(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error renaming team", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team": team,
+	})
+}
+
+// POST /team/delete
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req domain.DeleteTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Delete team request received", "team_name", req.TeamName)
+
+	if err := h.service.DeleteTeam(r.Context(), req); err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error deleting team", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": req.TeamName,
+	})
+}
+
+// POST /team/merge
+func (h *TeamHandler) MergeTeams(w http.ResponseWriter, r *http.Request) {
+	var req domain.MergeTeamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Merge teams request received", "source_team", req.SourceTeam, "target_team", req.TargetTeam)
+
+	result, err := h.service.MergeTeams(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error merging teams", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// maxImportSize ограничивает размер запроса импорта
+const maxImportSize = 10 << 20
+
+// POST /import
+func (h *TeamHandler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		h.logger.Warn("Invalid multipart form", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "request must be multipart/form-data up to 10MB"))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.logger.Warn("Import file is missing", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "file is required"))
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		h.logger.Error("Failed to read import file", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	// Без mode файл только проверяется, формат по умолчанию определяется по расширению
+	mode := domain.ImportMode(r.FormValue("mode"))
+	if mode == "" {
+		mode = domain.ImportModeValidate
+	}
+	format := domain.ImportFormat(strings.ToLower(r.FormValue("format")))
+	if format == "" {
+		format = importFormatFromFilename(header.Filename)
+	}
+
+	h.logger.Debug("Import request received", "file", header.Filename, "format", format, "mode", mode)
+
+	result, err := h.service.ImportTeams(r.Context(), domain.ImportTeamsRequest{
+		Format:  format,
+		Mode:    mode,
+		Content: string(content),
+	})
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error importing teams", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func importFormatFromFilename(filename string) domain.ImportFormat {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return domain.ImportFormatCSV
+	case ".yaml", ".yml":
+		return domain.ImportFormatYAML
+	default:
+		return ""
+	}
+}
+
+// GET /team/settings
+func (h *TeamHandler) GetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "team_name is required"))
+		return
+	}
+
+	h.logger.Debug("Get team settings request received", "team_name", teamName)
+
+	settings, err := h.service.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			respondError(w, http.StatusNotFound, appErr)
+			return
+		}
+		h.logger.Error("Internal error getting team settings", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"settings": settings,
+	})
+}
+
+// POST /team/settings
+func (h *TeamHandler) SetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var req domain.SetTeamSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Set team settings request received", "team_name", req.TeamName)
+
+	settings, err := h.service.SetTeamSettings(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error setting team settings", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"settings": settings,
+	})
+}
+
 // POST /team/deactivateUsers
 func (h *TeamHandler) DeactivateTeamUsers(w http.ResponseWriter, r *http.Request) {
 	var req domain.DeactivateTeamUsersRequest
@@ -109,3 +395,92 @@ func (h *TeamHandler) DeactivateTeamUsers(w http.ResponseWriter, r *http.Request
 
 	respondJSON(w, http.StatusOK, result)
 }
+
+// POST /team/addMembers
+func (h *TeamHandler) AddMembers(w http.ResponseWriter, r *http.Request) {
+	var req domain.AddTeamMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Add team members request received", "team_name", req.TeamName, "members", len(req.Members))
+
+	team, err := h.service.AddMembers(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error adding team members", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team": team,
+	})
+}
+
+// POST /team/removeMembers
+func (h *TeamHandler) RemoveMembers(w http.ResponseWriter, r *http.Request) {
+	var req domain.RemoveTeamMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Remove team members request received", "team_name", req.TeamName, "user_ids", req.UserIDs)
+
+	result, err := h.service.RemoveMembers(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error removing team members", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// POST /users/moveTeam
+func (h *TeamHandler) MoveUser(w http.ResponseWriter, r *http.Request) {
+	var req domain.MoveUserTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Move user request received", "user_id", req.UserID, "from_team", req.FromTeam, "team_name", req.TeamName)
+
+	result, err := h.service.MoveUser(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error moving user", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -53,6 +54,198 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// POST /users/setMaxOpenReviews
+func (h *UserHandler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req domain.SetMaxOpenReviewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Set user max open reviews request received", "user_id", req.UserID)
+
+	user, err := h.service.SetMaxOpenReviews(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error setting user max open reviews", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// POST /users/setLevel
+func (h *UserHandler) SetLevel(w http.ResponseWriter, r *http.Request) {
+	var req domain.SetUserLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Set user level request received", "user_id", req.UserID)
+
+	user, err := h.service.SetLevel(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error setting user level", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// POST /users/setSkills
+func (h *UserHandler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	h.updateSkills(w, r, h.service.SetSkills)
+}
+
+// POST /users/addSkills
+func (h *UserHandler) AddSkills(w http.ResponseWriter, r *http.Request) {
+	h.updateSkills(w, r, h.service.AddSkills)
+}
+
+// POST /users/removeSkills
+func (h *UserHandler) RemoveSkills(w http.ResponseWriter, r *http.Request) {
+	h.updateSkills(w, r, h.service.RemoveSkills)
+}
+
+func (h *UserHandler) updateSkills(w http.ResponseWriter, r *http.Request, update func(ctx context.Context, req domain.UserSkillsRequest) (*domain.User, error)) {
+	var req domain.UserSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Update user skills request received", "user_id", req.UserID)
+
+	user, err := update(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error updating user skills", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// POST /users/addOutOfOffice
+func (h *UserHandler) AddOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	var req domain.AddOutOfOfficeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Add out of office request received", "user_id", req.UserID)
+
+	period, err := h.service.AddOutOfOffice(r.Context(), req)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error adding out of office period", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"period": period,
+	})
+}
+
+// GET /users/getOutOfOffice
+func (h *UserHandler) GetOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "user_id is required"))
+		return
+	}
+
+	h.logger.Debug("Get out of office request received", "user_id", userID)
+
+	periods, err := h.service.GetOutOfOffice(r.Context(), userID)
+	if err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			respondError(w, http.StatusNotFound, appErr)
+			return
+		}
+		h.logger.Error("Internal error getting out of office periods", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, periods)
+}
+
+// POST /users/removeOutOfOffice
+func (h *UserHandler) RemoveOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	var req domain.RemoveOutOfOfficeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("Invalid request body", slog.Any("error", err))
+		respondError(w, http.StatusBadRequest, domain.NewAppError(domain.ErrCodeBadRequest, "invalid request body"))
+		return
+	}
+
+	h.logger.Debug("Remove out of office request received", "id", req.ID)
+
+	if err := h.service.RemoveOutOfOffice(r.Context(), req); err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			statusCode := http.StatusBadRequest
+			if appErr.Code == domain.ErrCodeNotFound {
+				statusCode = http.StatusNotFound
+			}
+			respondError(w, statusCode, appErr)
+			return
+		}
+		h.logger.Error("Internal error removing out of office period", slog.Any("error", err))
+		respondError(w, http.StatusInternalServerError, domain.NewAppError(domain.ErrCodeInternal, "internal server error"))
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"id": req.ID,
+	})
+}
+
 // GET /users/getReview
 func (h *UserHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
@@ -61,14 +254,17 @@ func (h *UserHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Debug("Get user reviews request received", "user_id", userID)
+	// Метки передаются повторяющимся параметром: ?label=bug&label=urgent
+	labels := r.URL.Query()["label"]
+
+	h.logger.Debug("Get user reviews request received", "user_id", userID, "labels", labels)
 
-	reviews, err := h.service.GetUserReviews(r.Context(), userID)
+	reviews, err := h.service.GetUserReviews(r.Context(), userID, labels)
 	if err != nil {
 		if appErr, ok := err.(*domain.AppError); ok {
 			statusCode := http.StatusNotFound
-			if appErr.Code == domain.ErrCodeNotFound {
-				statusCode = http.StatusNotFound
+			if appErr.Code == domain.ErrCodeBadRequest {
+				statusCode = http.StatusBadRequest
 			}
 			respondError(w, statusCode, appErr)
 			return
@@ -28,6 +28,7 @@ type Server struct {
 	teamHandler    *handlers.TeamHandler
 	userHandler    *handlers.UserHandler
 	prHandler      *handlers.PRHandler
+	ownersHandler  *handlers.CodeOwnersHandler
 	metricsService *usecase.MetricsService
 	auth           auth.Authenticator
 	metrics        metrics.Metrics
@@ -39,6 +40,7 @@ func NewServer(
 	teamHandler *handlers.TeamHandler,
 	userHandler *handlers.UserHandler,
 	prHandler *handlers.PRHandler,
+	ownersHandler *handlers.CodeOwnersHandler,
 	metricsService *usecase.MetricsService,
 	auth auth.Authenticator,
 	metrics metrics.Metrics,
@@ -49,6 +51,7 @@ func NewServer(
 		teamHandler:    teamHandler,
 		userHandler:    userHandler,
 		prHandler:      prHandler,
+		ownersHandler:  ownersHandler,
 		metricsService: metricsService,
 		auth:           auth,
 		metrics:        metrics,
@@ -82,16 +85,47 @@ func (s *Server) setupRouter() {
 	// Маршруты для команд
 	r.Post("/team/add", s.teamHandler.CreateTeam)
 	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/team/get", s.teamHandler.GetTeam)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/team/list", s.teamHandler.ListTeams)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/setParent", s.teamHandler.SetParentTeam)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/rename", s.teamHandler.RenameTeam)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/delete", s.teamHandler.DeleteTeam)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/merge", s.teamHandler.MergeTeams)
 	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/deactivateUsers", s.teamHandler.DeactivateTeamUsers)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/addMembers", s.teamHandler.AddMembers)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/removeMembers", s.teamHandler.RemoveMembers)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/team/settings", s.teamHandler.GetTeamSettings)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/team/settings", s.teamHandler.SetTeamSettings)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/import", s.teamHandler.Import)
 
 	// Маршруты для пользователей
 	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/setIsActive", s.userHandler.SetIsActive)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/moveTeam", s.teamHandler.MoveUser)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/setMaxOpenReviews", s.userHandler.SetMaxOpenReviews)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/setLevel", s.userHandler.SetLevel)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/setSkills", s.userHandler.SetSkills)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/addSkills", s.userHandler.AddSkills)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/removeSkills", s.userHandler.RemoveSkills)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/addOutOfOffice", s.userHandler.AddOutOfOffice)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/users/getOutOfOffice", s.userHandler.GetOutOfOffice)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/users/removeOutOfOffice", s.userHandler.RemoveOutOfOffice)
 	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/users/getReview", s.userHandler.GetReviews)
 
 	// Маршруты для pull request
 	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/create", s.prHandler.CreatePR)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/preview", s.prHandler.PreviewPR)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/ready", s.prHandler.MarkReady)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/close", s.prHandler.ClosePR)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/reopen", s.prHandler.ReopenPR)
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/update", s.prHandler.UpdatePR)
 	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/merge", s.prHandler.MergePR)
 	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/pullRequest/reassign", s.prHandler.ReassignReviewer)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Post("/pullRequest/review", s.prHandler.SubmitReview)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/pullRequest/assignmentTrace", s.prHandler.GetAssignmentTrace)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/pullRequest/history", s.prHandler.GetHistory)
+
+	// Маршруты для правил владения кодом
+	r.With(AuthMiddleware(s.auth, s.logger, true)).Post("/codeOwners/upload", s.ownersHandler.UploadCodeOwners)
+	r.With(AuthMiddleware(s.auth, s.logger, false)).Get("/codeOwners/get", s.ownersHandler.GetCodeOwners)
 
 	r.Get("/stats", s.getStats)
 
@@ -3,6 +3,8 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+
+	"pr-reviewer/internal/domain"
 )
 
 // GET /health
@@ -16,7 +18,14 @@ func (s *Server) healthCheck(w http.ResponseWriter, r *http.Request) {
 
 // GET /stats
 func (s *Server) getStats(w http.ResponseWriter, r *http.Request) {
-	stats, err := s.metricsService.GetAssignmentStats(r.Context())
+	query := r.URL.Query()
+	stats, err := s.metricsService.GetAssignmentStats(r.Context(), query.Get("team_name"), query.Get("subtree") == "true")
+	if err == domain.ErrTeamNotFound {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(domain.NewErrorResponse(domain.ErrTeamNotFound))
+		return
+	}
 	if err != nil {
 		s.logger.Error("Failed to get stats", "error", err)
 		w.Header().Set("Content-Type", "application/json")
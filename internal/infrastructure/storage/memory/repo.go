@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -9,19 +10,36 @@ import (
 )
 
 type MemoryRepository struct {
-	mu          sync.RWMutex
-	teams       map[string]*domain.Team
-	users       map[string]*domain.User
-	prs         map[string]*domain.PullRequest
-	prReviewers map[string][]string
+	mu           sync.RWMutex
+	teams        map[string]*domain.Team
+	teamSettings map[string]*domain.TeamSettings
+	users        map[string]*domain.User
+	memberships  map[string]map[string]bool
+	prs          map[string]*domain.PullRequest
+	prReviewers  map[string][]string
+	reviewStates map[string]map[string]domain.PRReviewer
+	codeOwners   map[string][]domain.CodeOwnerRule
+	outOfOffice  map[uint]*domain.OutOfOffice
+	nextOOOID    uint
+	assignments  map[string][]domain.AssignmentRecord
+	nextRecordID uint
+	prEvents     map[string][]domain.PREvent
+	nextEventID  uint
 }
 
 func NewMemoryRepository() *MemoryRepository {
 	return &MemoryRepository{
-		teams:       make(map[string]*domain.Team),
-		users:       make(map[string]*domain.User),
-		prs:         make(map[string]*domain.PullRequest),
-		prReviewers: make(map[string][]string),
+		teams:        make(map[string]*domain.Team),
+		teamSettings: make(map[string]*domain.TeamSettings),
+		users:        make(map[string]*domain.User),
+		memberships:  make(map[string]map[string]bool),
+		prs:          make(map[string]*domain.PullRequest),
+		prReviewers:  make(map[string][]string),
+		reviewStates: make(map[string]map[string]domain.PRReviewer),
+		codeOwners:   make(map[string][]domain.CodeOwnerRule),
+		outOfOffice:  make(map[uint]*domain.OutOfOffice),
+		assignments:  make(map[string][]domain.AssignmentRecord),
+		prEvents:     make(map[string][]domain.PREvent),
 	}
 }
 
@@ -34,17 +52,75 @@ func (r *MemoryRepository) CreateTeam(ctx context.Context, team *domain.Team, me
 	}
 
 	r.teams[team.TeamName] = &domain.Team{
-		TeamName: team.TeamName,
+		TeamName:              team.TeamName,
+		DefaultMaxOpenReviews: team.DefaultMaxOpenReviews,
+		ParentTeam:            team.ParentTeam,
 	}
 
+	r.memberships[team.TeamName] = make(map[string]bool, len(members))
 	for i := range members {
-		members[i].TeamName = team.TeamName
+		if members[i].TeamName == "" {
+			members[i].TeamName = team.TeamName
+		}
 		r.users[members[i].UserID] = &members[i]
+		r.memberships[team.TeamName][members[i].UserID] = true
+	}
+
+	return nil
+}
+
+// teamMembers возвращает участников команды; вызывается под блокировкой
+func (r *MemoryRepository) teamMembers(teamName string) []domain.User {
+	var members []domain.User
+	for userID := range r.memberships[teamName] {
+		if user, exists := r.users[userID]; exists {
+			members = append(members, *user)
+		}
+	}
+	return members
+}
+
+func (r *MemoryRepository) AddTeamMember(ctx context.Context, teamName, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.teams[teamName]; !exists {
+		return domain.ErrTeamNotFound
+	}
+	if _, exists := r.users[userID]; !exists {
+		return domain.ErrUserNotFound
 	}
 
+	if r.memberships[teamName] == nil {
+		r.memberships[teamName] = make(map[string]bool)
+	}
+	r.memberships[teamName][userID] = true
 	return nil
 }
 
+func (r *MemoryRepository) RemoveTeamMember(ctx context.Context, teamName, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.memberships[teamName], userID)
+	return nil
+}
+
+func (r *MemoryRepository) GetUserTeams(ctx context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]string, 0)
+	for teamName, members := range r.memberships {
+		if members[userID] {
+			teams = append(teams, teamName)
+		}
+	}
+	sort.Strings(teams)
+
+	return teams, nil
+}
+
 func (r *MemoryRepository) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -54,17 +130,125 @@ func (r *MemoryRepository) GetTeam(ctx context.Context, teamName string) (*domai
 		return nil, domain.ErrTeamNotFound
 	}
 
-	var members []domain.User
+	return &domain.Team{
+		TeamName:              team.TeamName,
+		DefaultMaxOpenReviews: team.DefaultMaxOpenReviews,
+		ParentTeam:            team.ParentTeam,
+		Members:               r.teamMembers(teamName),
+	}, nil
+}
+
+func (r *MemoryRepository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]domain.Team, 0, len(r.teams))
+	for _, team := range r.teams {
+		teams = append(teams, *team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].TeamName < teams[j].TeamName
+	})
+
+	return teams, nil
+}
+
+func (r *MemoryRepository) SetTeamParent(ctx context.Context, teamName, parentTeam string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	team, exists := r.teams[teamName]
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	team.ParentTeam = parentTeam
+	return nil
+}
+
+func (r *MemoryRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	team, exists := r.teams[oldName]
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+	if _, exists := r.teams[newName]; exists {
+		return domain.ErrTeamAlreadyExists
+	}
+
+	delete(r.teams, oldName)
+	team.TeamName = newName
+	r.teams[newName] = team
+	for _, t := range r.teams {
+		if t.ParentTeam == oldName {
+			t.ParentTeam = newName
+		}
+	}
+
+	if settings, exists := r.teamSettings[oldName]; exists {
+		delete(r.teamSettings, oldName)
+		settings.TeamName = newName
+		r.teamSettings[newName] = settings
+	}
+
+	if members, exists := r.memberships[oldName]; exists {
+		delete(r.memberships, oldName)
+		r.memberships[newName] = members
+	}
+
 	for _, user := range r.users {
-		if user.TeamName == teamName {
-			members = append(members, *user)
+		if user.TeamName == oldName {
+			user.TeamName = newName
 		}
 	}
 
-	return &domain.Team{
-		TeamName: team.TeamName,
-		Members:  members,
-	}, nil
+	for _, pr := range r.prs {
+		if pr.TeamName == oldName {
+			pr.TeamName = newName
+		}
+	}
+
+	oldKey := codeOwnersKey(domain.CodeOwnerScopeTeam, oldName)
+	if rules, exists := r.codeOwners[oldKey]; exists {
+		delete(r.codeOwners, oldKey)
+		for i := range rules {
+			rules[i].ScopeName = newName
+		}
+		r.codeOwners[codeOwnersKey(domain.CodeOwnerScopeTeam, newName)] = rules
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) DeleteTeam(ctx context.Context, teamName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.teams[teamName]; !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	delete(r.teams, teamName)
+	delete(r.teamSettings, teamName)
+	delete(r.memberships, teamName)
+	delete(r.codeOwners, codeOwnersKey(domain.CodeOwnerScopeTeam, teamName))
+
+	return nil
+}
+
+func (r *MemoryRepository) MoveTeamPRs(ctx context.Context, fromTeam, toTeam string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pr := range r.prs {
+		if pr.TeamName == fromTeam {
+			pr.TeamName = toTeam
+		}
+	}
+
+	return nil
 }
 
 func (r *MemoryRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
@@ -75,6 +259,28 @@ func (r *MemoryRepository) TeamExists(ctx context.Context, teamName string) (boo
 	return exists, nil
 }
 
+func (r *MemoryRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, exists := r.teamSettings[teamName]
+	if !exists {
+		return nil, domain.ErrTeamSettingsNotFound
+	}
+
+	settingsCopy := *settings
+	return &settingsCopy, nil
+}
+
+func (r *MemoryRepository) SaveTeamSettings(ctx context.Context, settings *domain.TeamSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settingsCopy := *settings
+	r.teamSettings[settings.TeamName] = &settingsCopy
+	return nil
+}
+
 func (r *MemoryRepository) CreateOrUpdateUser(ctx context.Context, user *domain.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -100,14 +306,7 @@ func (r *MemoryRepository) GetUsersByTeam(ctx context.Context, teamName string)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var users []domain.User
-	for _, user := range r.users {
-		if user.TeamName == teamName {
-			users = append(users, *user)
-		}
-	}
-
-	return users, nil
+	return r.teamMembers(teamName), nil
 }
 
 func (r *MemoryRepository) SetUserActive(ctx context.Context, userID string, isActive bool) error {
@@ -123,14 +322,66 @@ func (r *MemoryRepository) SetUserActive(ctx context.Context, userID string, isA
 	return nil
 }
 
+func (r *MemoryRepository) SetUserTeam(ctx context.Context, userID string, teamName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+
+	user.TeamName = teamName
+	return nil
+}
+
+func (r *MemoryRepository) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+
+	user.MaxOpenReviews = maxOpenReviews
+	return nil
+}
+
+func (r *MemoryRepository) SetUserLevel(ctx context.Context, userID string, level domain.UserLevel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+
+	user.Level = level
+	return nil
+}
+
+func (r *MemoryRepository) SetUserSkills(ctx context.Context, userID string, skills []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+
+	user.Skills = append([]string{}, skills...)
+	return nil
+}
+
 func (r *MemoryRepository) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var members []domain.User
-	for _, user := range r.users {
-		if user.TeamName == teamName && user.IsActive && user.UserID != excludeUserID {
-			members = append(members, *user)
+	for _, user := range r.teamMembers(teamName) {
+		if user.IsActive && user.UserID != excludeUserID {
+			members = append(members, user)
 		}
 	}
 
@@ -148,6 +399,14 @@ func (r *MemoryRepository) CreatePR(ctx context.Context, pr *domain.PullRequest,
 	r.prs[pr.PullRequestID] = pr
 	r.prReviewers[pr.PullRequestID] = reviewers
 
+	assignedAt := time.Now()
+	if pr.CreatedAt != nil {
+		assignedAt = *pr.CreatedAt
+	}
+	for _, reviewerID := range reviewers {
+		r.setPending(pr.PullRequestID, reviewerID, assignedAt)
+	}
+
 	return nil
 }
 
@@ -204,6 +463,35 @@ func (r *MemoryRepository) MergePR(ctx context.Context, prID string) error {
 	return nil
 }
 
+func (r *MemoryRepository) SetPRStatus(ctx context.Context, prID string, status domain.PRStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pr, exists := r.prs[prID]
+	if !exists {
+		return domain.ErrPRNotFound
+	}
+
+	pr.Status = status
+	return nil
+}
+
+func (r *MemoryRepository) UpdatePR(ctx context.Context, pr *domain.PullRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.prs[pr.PullRequestID]
+	if !exists {
+		return domain.ErrPRNotFound
+	}
+
+	stored.PullRequestName = pr.PullRequestName
+	stored.AuthorID = pr.AuthorID
+	stored.TeamName = pr.TeamName
+	stored.Labels = append([]string{}, pr.Labels...)
+	return nil
+}
+
 func (r *MemoryRepository) GetPRReviewers(ctx context.Context, prID string) ([]string, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -218,14 +506,36 @@ func (r *MemoryRepository) GetPRReviewers(ctx context.Context, prID string) ([]s
 	return result, nil
 }
 
-func (r *MemoryRepository) AddReviewer(ctx context.Context, prID, userID string) error {
+func (r *MemoryRepository) AddReviewer(ctx context.Context, prID, userID string, at time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.prReviewers[prID] = append(r.prReviewers[prID], userID)
+	r.setPending(prID, userID, at)
 	return nil
 }
 
+// setPending сбрасывает состояние ревьювера на PENDING с новым временем назначения
+func (r *MemoryRepository) setPending(prID, userID string, at time.Time) {
+	if r.reviewStates[prID] == nil {
+		r.reviewStates[prID] = make(map[string]domain.PRReviewer)
+	}
+	r.reviewStates[prID][userID] = domain.PRReviewer{
+		PullRequestID: prID,
+		ReviewerID:    userID,
+		State:         domain.ReviewStatePending,
+		AssignedAt:    at,
+	}
+}
+
+func (r *MemoryRepository) reviewerState(prID, userID string) domain.PRReviewer {
+	state, ok := r.reviewStates[prID][userID]
+	if !ok {
+		state = domain.PRReviewer{PullRequestID: prID, ReviewerID: userID, State: domain.ReviewStatePending}
+	}
+	return state
+}
+
 func (r *MemoryRepository) RemoveReviewer(ctx context.Context, prID, userID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -234,10 +544,112 @@ func (r *MemoryRepository) RemoveReviewer(ctx context.Context, prID, userID stri
 	for i, id := range reviewers {
 		if id == userID {
 			r.prReviewers[prID] = append(reviewers[:i], reviewers[i+1:]...)
+			delete(r.reviewStates[prID], userID)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) GetPRReviewerStates(ctx context.Context, prID string) ([]domain.PRReviewer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviewers := r.prReviewers[prID]
+	result := make([]domain.PRReviewer, len(reviewers))
+	for i, reviewerID := range reviewers {
+		result[i] = r.reviewerState(prID, reviewerID)
+	}
+
+	return result, nil
+}
+
+func (r *MemoryRepository) SubmitReview(ctx context.Context, prID, userID string, state domain.ReviewState, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, reviewerID := range r.prReviewers[prID] {
+		if reviewerID == userID {
+			if r.reviewStates[prID] == nil {
+				r.reviewStates[prID] = make(map[string]domain.PRReviewer)
+			}
+			review := r.reviewerState(prID, userID)
+			review.State = state
+			review.ReviewedAt = &at
+			r.reviewStates[prID][userID] = review
+			return nil
+		}
+	}
+
+	return domain.ErrReviewerNotAssigned
+}
+
+func (r *MemoryRepository) GetPendingReviews(ctx context.Context) ([]domain.PendingReview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reviews := make([]domain.PendingReview, 0)
+	for prID, pr := range r.prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+
+		for _, reviewerID := range r.prReviewers[prID] {
+			state := r.reviewerState(prID, reviewerID)
+			if state.State != domain.ReviewStatePending || state.EscalatedAt != nil {
+				continue
+			}
+			reviews = append(reviews, domain.PendingReview{
+				PullRequestID: prID,
+				AuthorID:      pr.AuthorID,
+				TeamName:      pr.TeamName,
+				ReviewerID:    reviewerID,
+				AssignedAt:    state.AssignedAt,
+			})
+		}
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].PullRequestID < reviews[j].PullRequestID
+	})
+
+	return reviews, nil
+}
+
+func (r *MemoryRepository) MarkReviewEscalated(ctx context.Context, prID, userID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, reviewerID := range r.prReviewers[prID] {
+		if reviewerID == userID {
+			if r.reviewStates[prID] == nil {
+				r.reviewStates[prID] = make(map[string]domain.PRReviewer)
+			}
+			review := r.reviewerState(prID, userID)
+			review.EscalatedAt = &at
+			r.reviewStates[prID][userID] = review
 			return nil
 		}
 	}
 
+	return domain.ErrReviewerNotAssigned
+}
+
+func (r *MemoryRepository) ResetReviewerAssignments(ctx context.Context, prID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, reviewerID := range r.prReviewers[prID] {
+		if r.reviewStates[prID] == nil {
+			r.reviewStates[prID] = make(map[string]domain.PRReviewer)
+		}
+		review := r.reviewerState(prID, reviewerID)
+		review.AssignedAt = at
+		review.EscalatedAt = nil
+		r.reviewStates[prID][reviewerID] = review
+	}
+
 	return nil
 }
 
@@ -278,6 +690,31 @@ func (r *MemoryRepository) IsReviewerAssigned(ctx context.Context, prID, userID
 	return false, nil
 }
 
+func (r *MemoryRepository) GetReviewPairings(ctx context.Context, authorID string, since time.Time) ([]domain.ReviewPairing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pairings := make([]domain.ReviewPairing, 0)
+	for prID, records := range r.assignments {
+		pr, exists := r.prs[prID]
+		if !exists || pr.AuthorID != authorID {
+			continue
+		}
+
+		for _, record := range records {
+			if record.AssignedAt.Before(since) {
+				continue
+			}
+			pairings = append(pairings, domain.ReviewPairing{
+				ReviewerID: record.ReviewerID,
+				PairedAt:   record.AssignedAt,
+			})
+		}
+	}
+
+	return pairings, nil
+}
+
 func (r *MemoryRepository) DeactivateUsers(ctx context.Context, userIDs []string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -328,7 +765,7 @@ func (r *MemoryRepository) GetOpenPRsWithReviewers(ctx context.Context, reviewer
 	return affectedPRs, reviewersMap, nil
 }
 
-func (r *MemoryRepository) BulkReassignReviewers(ctx context.Context, reassignments []domain.PRReassignment) error {
+func (r *MemoryRepository) BulkReassignReviewers(ctx context.Context, reassignments []domain.PRReassignment, at time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -341,9 +778,11 @@ func (r *MemoryRepository) BulkReassignReviewers(ctx context.Context, reassignme
 				newReviewers = append(newReviewers, revID)
 			}
 		}
+		delete(r.reviewStates[reassign.PullRequestID], reassign.OldReviewerID)
 
 		if reassign.NewReviewerID != "" {
 			newReviewers = append(newReviewers, reassign.NewReviewerID)
+			r.setPending(reassign.PullRequestID, reassign.NewReviewerID, at)
 		}
 
 		r.prReviewers[reassign.PullRequestID] = newReviewers
@@ -352,6 +791,174 @@ func (r *MemoryRepository) BulkReassignReviewers(ctx context.Context, reassignme
 	return nil
 }
 
+func codeOwnersKey(scopeType domain.CodeOwnerScope, scopeName string) string {
+	return string(scopeType) + "/" + scopeName
+}
+
+func (r *MemoryRepository) ReplaceCodeOwnerRules(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string, rules []domain.CodeOwnerRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := make([]domain.CodeOwnerRule, len(rules))
+	copy(stored, rules)
+	r.codeOwners[codeOwnersKey(scopeType, scopeName)] = stored
+
+	return nil
+}
+
+func (r *MemoryRepository) GetCodeOwnerRules(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string) ([]domain.CodeOwnerRule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.codeOwners[codeOwnersKey(scopeType, scopeName)]
+	rules := make([]domain.CodeOwnerRule, len(stored))
+	copy(rules, stored)
+
+	return rules, nil
+}
+
+func (r *MemoryRepository) SaveAssignmentRecords(ctx context.Context, records []domain.AssignmentRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range records {
+		r.nextRecordID++
+		records[i].ID = r.nextRecordID
+		prID := records[i].PullRequestID
+		r.assignments[prID] = append(r.assignments[prID], records[i])
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) GetAssignmentRecords(ctx context.Context, prID string) ([]domain.AssignmentRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.assignments[prID]
+	records := make([]domain.AssignmentRecord, len(stored))
+	copy(records, stored)
+
+	return records, nil
+}
+
+func (r *MemoryRepository) AddPREvent(ctx context.Context, event *domain.PREvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextEventID++
+	event.ID = r.nextEventID
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	r.prEvents[event.PullRequestID] = append(r.prEvents[event.PullRequestID], *event)
+
+	return nil
+}
+
+func (r *MemoryRepository) GetPREvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.prEvents[prID]
+	events := make([]domain.PREvent, len(stored))
+	copy(events, stored)
+
+	return events, nil
+}
+
+func (r *MemoryRepository) CreateOutOfOffice(ctx context.Context, period *domain.OutOfOffice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextOOOID++
+	period.ID = r.nextOOOID
+	periodCopy := *period
+	r.outOfOffice[period.ID] = &periodCopy
+
+	return nil
+}
+
+func (r *MemoryRepository) GetUserOutOfOffice(ctx context.Context, userID string) ([]domain.OutOfOffice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	periods := make([]domain.OutOfOffice, 0)
+	for _, period := range r.outOfOffice {
+		if period.UserID == userID {
+			periods = append(periods, *period)
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].StartsAt.Before(periods[j].StartsAt)
+	})
+
+	return periods, nil
+}
+
+func (r *MemoryRepository) DeleteOutOfOffice(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.outOfOffice[id]; !exists {
+		return domain.ErrOutOfOfficeNotFound
+	}
+
+	delete(r.outOfOffice, id)
+	return nil
+}
+
+func (r *MemoryRepository) GetOutOfOfficeUserIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userSet := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		userSet[id] = true
+	}
+
+	absent := make(map[string]bool)
+	for _, period := range r.outOfOffice {
+		if userSet[period.UserID] && !at.Before(period.StartsAt) && at.Before(period.EndsAt) {
+			absent[period.UserID] = true
+		}
+	}
+
+	return absent, nil
+}
+
+func (r *MemoryRepository) GetPendingOutOfOfficeReassignments(ctx context.Context, at time.Time) ([]domain.OutOfOffice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	periods := make([]domain.OutOfOffice, 0)
+	for _, period := range r.outOfOffice {
+		if period.ReassignReviews && !period.ReviewsReassigned && !at.Before(period.StartsAt) && at.Before(period.EndsAt) {
+			periods = append(periods, *period)
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].ID < periods[j].ID
+	})
+
+	return periods, nil
+}
+
+func (r *MemoryRepository) MarkOutOfOfficeReassigned(ctx context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	period, exists := r.outOfOffice[id]
+	if !exists {
+		return domain.ErrOutOfOfficeNotFound
+	}
+
+	period.ReviewsReassigned = true
+	return nil
+}
+
 func (r *MemoryRepository) GetAssignmentStats(ctx context.Context) (map[string]int, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -365,3 +972,29 @@ func (r *MemoryRepository) GetAssignmentStats(ctx context.Context) (map[string]i
 
 	return stats, nil
 }
+
+func (r *MemoryRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userSet := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		userSet[id] = true
+	}
+
+	counts := make(map[string]int, len(userIDs))
+	for prID, reviewers := range r.prReviewers {
+		pr, exists := r.prs[prID]
+		if !exists || pr.Status != domain.PRStatusOpen {
+			continue
+		}
+
+		for _, reviewerID := range reviewers {
+			if userSet[reviewerID] {
+				counts[reviewerID]++
+			}
+		}
+	}
+
+	return counts, nil
+}
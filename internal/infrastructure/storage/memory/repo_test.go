@@ -3,6 +3,7 @@ package memory
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,6 +206,145 @@ func TestMemoryRepository_MergePR(t *testing.T) {
 	assert.NotNil(t, result.MergedAt)
 }
 
+func TestMemoryRepository_SetPRStatus(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2"}))
+	require.NoError(t, repo.SetPRStatus(ctx, "pr-1", domain.PRStatusClosed))
+	assert.Equal(t, domain.ErrPRNotFound, repo.SetPRStatus(ctx, "unknown", domain.PRStatusClosed))
+
+	result, err := repo.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusClosed, result.Status)
+	assert.Nil(t, result.MergedAt)
+
+	prs, _, err := repo.GetOpenPRsWithReviewers(ctx, []string{"u2"})
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
+
+func TestMemoryRepository_TeamMembership(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{{UserID: "u1", IsActive: true}}))
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "frontend"}, nil))
+
+	require.NoError(t, repo.AddTeamMember(ctx, "frontend", "u1"))
+	assert.Equal(t, domain.ErrUserNotFound, repo.AddTeamMember(ctx, "frontend", "unknown"))
+	assert.Equal(t, domain.ErrTeamNotFound, repo.AddTeamMember(ctx, "missing", "u1"))
+
+	teams, err := repo.GetUserTeams(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "frontend"}, teams)
+
+	members, err := repo.GetActiveTeamMembers(ctx, "frontend", "")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, "backend", members[0].TeamName)
+
+	// Основная команда меняется независимо от членства
+	require.NoError(t, repo.SetUserTeam(ctx, "u1", "frontend"))
+	assert.Equal(t, domain.ErrUserNotFound, repo.SetUserTeam(ctx, "unknown", "frontend"))
+	require.NoError(t, repo.RemoveTeamMember(ctx, "backend", "u1"))
+
+	team, err := repo.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Empty(t, team.Members)
+
+	user, err := repo.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName)
+
+	teams, err = repo.GetUserTeams(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, teams)
+}
+
+func TestMemoryRepository_TeamParent(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "org"}, nil))
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "dept", ParentTeam: "org"}, nil))
+
+	require.NoError(t, repo.SetTeamParent(ctx, "org", "dept"))
+	assert.Equal(t, domain.ErrTeamNotFound, repo.SetTeamParent(ctx, "missing", "org"))
+
+	teams, err := repo.ListTeams(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "dept", teams[0].TeamName)
+	assert.Equal(t, "org", teams[0].ParentTeam)
+	assert.Equal(t, "dept", teams[1].ParentTeam)
+}
+
+func TestMemoryRepository_RenameAndDeleteTeam(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "old"}, []domain.User{{UserID: "u1", Username: "Alice", IsActive: true}}))
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "child", ParentTeam: "old"}, nil))
+	require.NoError(t, repo.SaveTeamSettings(ctx, domain.DefaultTeamSettings("old")))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "old", Status: domain.PRStatusOpen}, nil))
+
+	assert.Equal(t, domain.ErrTeamAlreadyExists, repo.RenameTeam(ctx, "old", "child"))
+	assert.Equal(t, domain.ErrTeamNotFound, repo.RenameTeam(ctx, "missing", "new"))
+	require.NoError(t, repo.RenameTeam(ctx, "old", "new"))
+
+	team, err := repo.GetTeam(ctx, "new")
+	require.NoError(t, err)
+	assert.Len(t, team.Members, 1)
+
+	user, err := repo.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "new", user.TeamName)
+
+	child, err := repo.GetTeam(ctx, "child")
+	require.NoError(t, err)
+	assert.Equal(t, "new", child.ParentTeam)
+
+	settings, err := repo.GetTeamSettings(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, "new", settings.TeamName)
+
+	require.NoError(t, repo.MoveTeamPRs(ctx, "new", ""))
+	pr, err := repo.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, pr.TeamName)
+
+	require.NoError(t, repo.DeleteTeam(ctx, "new"))
+	assert.Equal(t, domain.ErrTeamNotFound, repo.DeleteTeam(ctx, "new"))
+	_, err = repo.GetTeamSettings(ctx, "new")
+	assert.Equal(t, domain.ErrTeamSettingsNotFound, err)
+
+	teams, err := repo.GetUserTeams(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+}
+
+func TestMemoryRepository_UpdatePR(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Old", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2"}))
+
+	labels := []string{"bug"}
+	require.NoError(t, repo.UpdatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "New", AuthorID: "u3", Labels: labels}))
+	assert.Equal(t, domain.ErrPRNotFound, repo.UpdatePR(ctx, &domain.PullRequest{PullRequestID: "unknown"}))
+
+	// Хранилище не должно зависеть от переданного среза
+	labels[0] = "changed"
+
+	result, err := repo.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "New", result.PullRequestName)
+	assert.Equal(t, "u3", result.AuthorID)
+	assert.Equal(t, []string{"bug"}, result.Labels)
+	assert.Equal(t, domain.PRStatusOpen, result.Status)
+}
+
 func TestMemoryRepository_GetUserReviews(t *testing.T) {
 	repo := NewMemoryRepository()
 	ctx := context.Background()
@@ -269,3 +409,137 @@ func TestMemoryRepository_GetActiveTeamMembers(t *testing.T) {
 	assert.Equal(t, "u3", activeMembers[0].UserID)
 	assert.True(t, activeMembers[0].IsActive)
 }
+
+func TestMemoryRepository_GetOpenReviewCounts(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	err := repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2", "u3"})
+	require.NoError(t, err)
+	err = repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2"})
+	require.NoError(t, err)
+	err = repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-3", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u3"})
+	require.NoError(t, err)
+	err = repo.MergePR(ctx, "pr-3")
+	require.NoError(t, err)
+
+	counts, err := repo.GetOpenReviewCounts(ctx, []string{"u2", "u3", "u4"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts["u2"])
+	assert.Equal(t, 1, counts["u3"])
+	assert.Equal(t, 0, counts["u4"])
+}
+
+func TestMemoryRepository_ReviewStates(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	now := time.Now()
+
+	createdAt := now.Add(-time.Hour)
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", CreatedAt: &createdAt}, []string{"u2", "u3"}))
+	require.NoError(t, repo.SubmitReview(ctx, "pr-1", "u2", domain.ReviewStateApproved, now))
+	assert.Equal(t, domain.ErrReviewerNotAssigned, repo.SubmitReview(ctx, "pr-1", "u4", domain.ReviewStateApproved, now))
+
+	states, err := repo.GetPRReviewerStates(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, domain.ReviewStateApproved, states[0].State)
+	assert.Equal(t, domain.ReviewStatePending, states[1].State)
+	assert.Equal(t, createdAt, states[1].AssignedAt)
+
+	// Решение снятого ревьювера не должно вернуться при повторном назначении
+	require.NoError(t, repo.RemoveReviewer(ctx, "pr-1", "u2"))
+	require.NoError(t, repo.AddReviewer(ctx, "pr-1", "u2", now))
+	states, err = repo.GetPRReviewerStates(ctx, "pr-1")
+	require.NoError(t, err)
+	for _, state := range states {
+		assert.Equal(t, domain.ReviewStatePending, state.State)
+		if state.ReviewerID == "u2" {
+			assert.Equal(t, now, state.AssignedAt)
+		}
+	}
+
+	require.NoError(t, repo.MarkReviewEscalated(ctx, "pr-1", "u3", now))
+	reopenedAt := now.Add(time.Hour)
+	require.NoError(t, repo.ResetReviewerAssignments(ctx, "pr-1", reopenedAt))
+	states, err = repo.GetPRReviewerStates(ctx, "pr-1")
+	require.NoError(t, err)
+	for _, state := range states {
+		assert.Equal(t, reopenedAt, state.AssignedAt)
+		assert.Nil(t, state.EscalatedAt)
+	}
+}
+
+func TestMemoryRepository_PREvents(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.AddPREvent(ctx, &domain.PREvent{PullRequestID: "pr-1", Type: domain.PREventMerged, Forced: true, Conditions: []string{"0 of 1 required approvals"}}))
+	require.NoError(t, repo.AddPREvent(ctx, &domain.PREvent{PullRequestID: "pr-2", Type: domain.PREventMerged}))
+
+	events, err := repo.GetPREvents(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.NotZero(t, events[0].ID)
+	assert.False(t, events[0].CreatedAt.IsZero())
+	assert.True(t, events[0].Forced)
+
+	events, err = repo.GetPREvents(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestMemoryRepository_GetReviewPairings(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", CreatedAt: &old}, []string{"u2", "u3"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u1", CreatedAt: &now}, []string{"u2"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-3", AuthorID: "u4", CreatedAt: &now}, []string{"u2"}))
+	require.NoError(t, repo.SaveAssignmentRecords(ctx, []domain.AssignmentRecord{
+		{PullRequestID: "pr-1", ReviewerID: "u2", Source: domain.AssignmentSourceTeam, AssignedAt: now},
+		{PullRequestID: "pr-1", ReviewerID: "u3", Source: domain.AssignmentSourceTeam, AssignedAt: now},
+		{PullRequestID: "pr-2", ReviewerID: "u2", Source: domain.AssignmentSourceTeam, AssignedAt: old},
+		{PullRequestID: "pr-3", ReviewerID: "u2", Source: domain.AssignmentSourceTeam, AssignedAt: now},
+	}))
+	// Пара учитывается по времени назначения и сохраняется после снятия ревьювера
+	require.NoError(t, repo.RemoveReviewer(ctx, "pr-1", "u3"))
+
+	pairings, err := repo.GetReviewPairings(ctx, "u1", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []domain.ReviewPairing{
+		{ReviewerID: "u2", PairedAt: now},
+		{ReviewerID: "u3", PairedAt: now},
+	}, pairings)
+}
+
+func TestMemoryRepository_OutOfOffice(t *testing.T) {
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	now := time.Now()
+
+	current := &domain.OutOfOffice{UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), ReassignReviews: true}
+	require.NoError(t, repo.CreateOutOfOffice(ctx, current))
+	future := &domain.OutOfOffice{UserID: "u2", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), ReassignReviews: true}
+	require.NoError(t, repo.CreateOutOfOffice(ctx, future))
+	assert.NotEqual(t, current.ID, future.ID)
+
+	absent, err := repo.GetOutOfOfficeUserIDs(ctx, []string{"u1", "u2", "u3"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"u1": true}, absent)
+
+	pending, err := repo.GetPendingOutOfOfficeReassignments(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, current.ID, pending[0].ID)
+
+	require.NoError(t, repo.MarkOutOfOfficeReassigned(ctx, current.ID))
+	pending, err = repo.GetPendingOutOfOfficeReassignments(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, repo.DeleteOutOfOffice(ctx, future.ID))
+	assert.Equal(t, domain.ErrOutOfOfficeNotFound, repo.DeleteOutOfOffice(ctx, future.ID))
+}
@@ -7,6 +7,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	"pr-reviewer/internal/domain"
@@ -24,17 +25,41 @@ func NewPostgresRepository(dsn string) (*PostgresRepository, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	if err := db.AutoMigrate(&domain.Team{}, &domain.User{}, &domain.PullRequest{}, &domain.PRReviewer{}); err != nil {
+	if err := db.AutoMigrate(&domain.Team{}, &domain.User{}, &domain.TeamMembership{}, &domain.PullRequest{}, &domain.PRReviewer{}, &domain.TeamSettings{}, &domain.CodeOwnerRule{}, &domain.OutOfOffice{}, &domain.AssignmentRecord{}, &domain.PREvent{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer ON pr_reviewers(reviewer_id)")
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_pr_status ON pull_requests(status)")
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_users_team_active ON users(team_name, is_active)")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_out_of_offices_period ON out_of_offices(starts_at, ends_at)")
+
+	// До появления членства users.team_name ссылался на teams внешним ключом без ON UPDATE CASCADE.
+	// Теперь это лишь основная команда (может быть пустой), а ключ мешал переименованию команд.
+	if err := db.Exec(dropLegacyUserTeamFK).Error; err != nil {
+		return nil, fmt.Errorf("failed to drop legacy users.team_name foreign key: %w", err)
+	}
+
+	// Пользователи, созданные до появления членства, становятся участниками своей основной команды
+	db.Exec("INSERT INTO team_memberships (team_name, user_id) SELECT team_name, user_id FROM users WHERE team_name IS NOT NULL AND team_name <> '' ON CONFLICT DO NOTHING")
 
 	return &PostgresRepository{db: db}, nil
 }
 
+// dropLegacyUserTeamFK удаляет внешние ключи users → teams, созданные AutoMigrate
+// по прежнему Team.Members; имя ограничения не фиксируем и ищем его в каталоге
+const dropLegacyUserTeamFK = `
+DO $$
+DECLARE c record;
+BEGIN
+	FOR c IN
+		SELECT conname FROM pg_constraint
+		WHERE contype = 'f' AND conrelid = 'users'::regclass AND confrelid = 'teams'::regclass
+	LOOP
+		EXECUTE format('ALTER TABLE users DROP CONSTRAINT %I', c.conname);
+	END LOOP;
+END $$`
+
 func (r *PostgresRepository) Close() error {
 	sqlDB, err := r.db.DB()
 	if err != nil {
@@ -59,15 +84,20 @@ func (r *PostgresRepository) getDB(ctx context.Context) *gorm.DB {
 func (r *PostgresRepository) CreateTeam(ctx context.Context, team *domain.Team, members []domain.User) error {
 	db := r.getDB(ctx)
 
-	if err := db.Create(&domain.Team{TeamName: team.TeamName}).Error; err != nil {
+	if err := db.Create(&domain.Team{TeamName: team.TeamName, DefaultMaxOpenReviews: team.DefaultMaxOpenReviews, ParentTeam: team.ParentTeam}).Error; err != nil {
 		return err
 	}
 
 	for i := range members {
-		members[i].TeamName = team.TeamName
+		if members[i].TeamName == "" {
+			members[i].TeamName = team.TeamName
+		}
 		if err := db.Save(&members[i]).Error; err != nil {
 			return err
 		}
+		if err := r.AddTeamMember(ctx, team.TeamName, members[i].UserID); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -77,16 +107,149 @@ func (r *PostgresRepository) GetTeam(ctx context.Context, teamName string) (*dom
 	db := r.getDB(ctx)
 
 	var team domain.Team
-	if err := db.Preload("Members").Where("team_name = ?", teamName).First(&team).Error; err != nil {
+	if err := db.Where("team_name = ?", teamName).First(&team).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrTeamNotFound
 		}
 		return nil, err
 	}
 
+	members, err := r.GetUsersByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	team.Members = members
+
 	return &team, nil
 }
 
+func (r *PostgresRepository) ListTeams(ctx context.Context) ([]domain.Team, error) {
+	db := r.getDB(ctx)
+
+	var teams []domain.Team
+	if err := db.Order("team_name").Find(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (r *PostgresRepository) SetTeamParent(ctx context.Context, teamName, parentTeam string) error {
+	db := r.getDB(ctx)
+
+	var value interface{} = parentTeam
+	if parentTeam == "" {
+		value = gorm.Expr("NULL")
+	}
+
+	result := db.Model(&domain.Team{}).Where("team_name = ?", teamName).Update("parent_team", value)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) RenameTeam(ctx context.Context, oldName, newName string) error {
+	db := r.getDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.Team{}).Where("team_name = ?", newName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrTeamAlreadyExists
+	}
+
+	result := db.Model(&domain.Team{}).Where("team_name = ?", oldName).Update("team_name", newName)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	references := []struct {
+		model  interface{}
+		column string
+	}{
+		{&domain.Team{}, "parent_team"},
+		{&domain.TeamSettings{}, "team_name"},
+		{&domain.TeamMembership{}, "team_name"},
+		{&domain.User{}, "team_name"},
+		{&domain.PullRequest{}, "team_name"},
+	}
+	for _, ref := range references {
+		if err := db.Model(ref.model).Where(ref.column+" = ?", oldName).Update(ref.column, newName).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Model(&domain.CodeOwnerRule{}).
+		Where("scope_type = ? AND scope_name = ?", domain.CodeOwnerScopeTeam, oldName).
+		Update("scope_name", newName).Error
+}
+
+func (r *PostgresRepository) DeleteTeam(ctx context.Context, teamName string) error {
+	db := r.getDB(ctx)
+
+	result := db.Where("team_name = ?", teamName).Delete(&domain.Team{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	if err := db.Where("team_name = ?", teamName).Delete(&domain.TeamSettings{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("team_name = ?", teamName).Delete(&domain.TeamMembership{}).Error; err != nil {
+		return err
+	}
+
+	return db.Where("scope_type = ? AND scope_name = ?", domain.CodeOwnerScopeTeam, teamName).
+		Delete(&domain.CodeOwnerRule{}).Error
+}
+
+func (r *PostgresRepository) MoveTeamPRs(ctx context.Context, fromTeam, toTeam string) error {
+	db := r.getDB(ctx)
+	return db.Model(&domain.PullRequest{}).Where("team_name = ?", fromTeam).Update("team_name", toTeam).Error
+}
+
+// membersQuery выбирает пользователей, состоящих в команде
+func (r *PostgresRepository) membersQuery(ctx context.Context, teamName string) *gorm.DB {
+	return r.getDB(ctx).
+		Joins("JOIN team_memberships ON team_memberships.user_id = users.user_id").
+		Where("team_memberships.team_name = ?", teamName)
+}
+
+func (r *PostgresRepository) AddTeamMember(ctx context.Context, teamName, userID string) error {
+	db := r.getDB(ctx)
+	return db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.TeamMembership{TeamName: teamName, UserID: userID}).Error
+}
+
+func (r *PostgresRepository) RemoveTeamMember(ctx context.Context, teamName, userID string) error {
+	db := r.getDB(ctx)
+	return db.Where("team_name = ? AND user_id = ?", teamName, userID).Delete(&domain.TeamMembership{}).Error
+}
+
+func (r *PostgresRepository) GetUserTeams(ctx context.Context, userID string) ([]string, error) {
+	db := r.getDB(ctx)
+
+	teams := make([]string, 0)
+	if err := db.Model(&domain.TeamMembership{}).Where("user_id = ?", userID).Order("team_name").Pluck("team_name", &teams).Error; err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
 func (r *PostgresRepository) TeamExists(ctx context.Context, teamName string) (bool, error) {
 	db := r.getDB(ctx)
 
@@ -98,6 +261,25 @@ func (r *PostgresRepository) TeamExists(ctx context.Context, teamName string) (b
 	return count > 0, nil
 }
 
+func (r *PostgresRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	db := r.getDB(ctx)
+
+	var settings domain.TeamSettings
+	if err := db.Where("team_name = ?", teamName).First(&settings).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTeamSettingsNotFound
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *PostgresRepository) SaveTeamSettings(ctx context.Context, settings *domain.TeamSettings) error {
+	db := r.getDB(ctx)
+	return db.Save(settings).Error
+}
+
 func (r *PostgresRepository) CreateOrUpdateUser(ctx context.Context, user *domain.User) error {
 	db := r.getDB(ctx)
 	return db.Save(user).Error
@@ -118,10 +300,8 @@ func (r *PostgresRepository) GetUser(ctx context.Context, userID string) (*domai
 }
 
 func (r *PostgresRepository) GetUsersByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
-	db := r.getDB(ctx)
-
 	var users []domain.User
-	if err := db.Where("team_name = ?", teamName).Find(&users).Error; err != nil {
+	if err := r.membersQuery(ctx, teamName).Find(&users).Error; err != nil {
 		return nil, err
 	}
 
@@ -143,14 +323,80 @@ func (r *PostgresRepository) SetUserActive(ctx context.Context, userID string, i
 	return nil
 }
 
-func (r *PostgresRepository) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
+func (r *PostgresRepository) SetUserTeam(ctx context.Context, userID string, teamName string) error {
 	db := r.getDB(ctx)
 
+	// Пустое имя пишется как NULL: у пользователя нет основной команды
+	var value interface{} = teamName
+	if teamName == "" {
+		value = gorm.Expr("NULL")
+	}
+
+	result := db.Model(&domain.User{}).Where("user_id = ?", userID).Update("team_name", value)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	db := r.getDB(ctx)
+	result := db.Model(&domain.User{}).Where("user_id = ?", userID).Update("max_open_reviews", maxOpenReviews)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) SetUserLevel(ctx context.Context, userID string, level domain.UserLevel) error {
+	db := r.getDB(ctx)
+	result := db.Model(&domain.User{}).Where("user_id = ?", userID).Update("level", level)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) SetUserSkills(ctx context.Context, userID string, skills []string) error {
+	db := r.getDB(ctx)
+	// Updates со структурой применяет json-сериализатор поля
+	result := db.Model(&domain.User{}).Where("user_id = ?", userID).Select("skills").Updates(&domain.User{Skills: skills})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error) {
 	var users []domain.User
-	query := db.Where("team_name = ? AND is_active = ?", teamName, true)
+	query := r.membersQuery(ctx, teamName).Where("users.is_active = ?", true)
 
 	if excludeUserID != "" {
-		query = query.Where("user_id != ?", excludeUserID)
+		query = query.Where("users.user_id != ?", excludeUserID)
 	}
 
 	if err := query.Find(&users).Error; err != nil {
@@ -167,10 +413,16 @@ func (r *PostgresRepository) CreatePR(ctx context.Context, pr *domain.PullReques
 		return err
 	}
 
+	assignedAt := time.Now()
+	if pr.CreatedAt != nil {
+		assignedAt = *pr.CreatedAt
+	}
 	for _, reviewerID := range reviewers {
 		prReviewer := domain.PRReviewer{
 			PullRequestID: pr.PullRequestID,
 			ReviewerID:    reviewerID,
+			State:         domain.ReviewStatePending,
+			AssignedAt:    assignedAt,
 		}
 		if err := db.Create(&prReviewer).Error; err != nil {
 			return err
@@ -240,11 +492,52 @@ func (r *PostgresRepository) MergePR(ctx context.Context, prID string) error {
 	return nil
 }
 
+func (r *PostgresRepository) SetPRStatus(ctx context.Context, prID string, status domain.PRStatus) error {
+	db := r.getDB(ctx)
+
+	result := db.Model(&domain.PullRequest{}).
+		Where("pull_request_id = ?", prID).
+		Update("status", status)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPRNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) UpdatePR(ctx context.Context, pr *domain.PullRequest) error {
+	db := r.getDB(ctx)
+
+	// Select нужен, чтобы сохранить и пустой список меток
+	result := db.Model(&domain.PullRequest{}).
+		Where("pull_request_id = ?", pr.PullRequestID).
+		Select("pull_request_name", "author_id", "team_name", "labels").
+		Updates(&domain.PullRequest{
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			TeamName:        pr.TeamName,
+			Labels:          pr.Labels,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPRNotFound
+	}
+
+	return nil
+}
+
 func (r *PostgresRepository) GetPRReviewers(ctx context.Context, prID string) ([]string, error) {
 	db := r.getDB(ctx)
 
 	var prReviewers []domain.PRReviewer
-	if err := db.Where("pull_request_id = ?", prID).Find(&prReviewers).Error; err != nil {
+	if err := db.Where("pull_request_id = ?", prID).Order("assigned_at, reviewer_id").Find(&prReviewers).Error; err != nil {
 		return nil, err
 	}
 
@@ -256,11 +549,13 @@ func (r *PostgresRepository) GetPRReviewers(ctx context.Context, prID string) ([
 	return reviewerIDs, nil
 }
 
-func (r *PostgresRepository) AddReviewer(ctx context.Context, prID, userID string) error {
+func (r *PostgresRepository) AddReviewer(ctx context.Context, prID, userID string, at time.Time) error {
 	db := r.getDB(ctx)
 	prReviewer := domain.PRReviewer{
 		PullRequestID: prID,
 		ReviewerID:    userID,
+		State:         domain.ReviewStatePending,
+		AssignedAt:    at,
 	}
 
 	return db.Create(&prReviewer).Error
@@ -273,6 +568,37 @@ func (r *PostgresRepository) RemoveReviewer(ctx context.Context, prID, userID st
 		Delete(&domain.PRReviewer{}).Error
 }
 
+func (r *PostgresRepository) GetPRReviewerStates(ctx context.Context, prID string) ([]domain.PRReviewer, error) {
+	db := r.getDB(ctx)
+
+	var prReviewers []domain.PRReviewer
+	if err := db.Where("pull_request_id = ?", prID).Order("assigned_at, reviewer_id").Find(&prReviewers).Error; err != nil {
+		return nil, err
+	}
+
+	return prReviewers, nil
+}
+
+func (r *PostgresRepository) SubmitReview(ctx context.Context, prID, userID string, state domain.ReviewState, at time.Time) error {
+	db := r.getDB(ctx)
+	result := db.Model(&domain.PRReviewer{}).
+		Where("pull_request_id = ? AND reviewer_id = ?", prID, userID).
+		Updates(map[string]interface{}{
+			"state":       state,
+			"reviewed_at": at,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrReviewerNotAssigned
+	}
+
+	return nil
+}
+
 func (r *PostgresRepository) GetUserReviews(ctx context.Context, userID string) ([]domain.PullRequest, error) {
 	db := r.getDB(ctx)
 
@@ -302,6 +628,68 @@ func (r *PostgresRepository) IsReviewerAssigned(ctx context.Context, prID, userI
 	return count > 0, nil
 }
 
+func (r *PostgresRepository) GetPendingReviews(ctx context.Context) ([]domain.PendingReview, error) {
+	db := r.getDB(ctx)
+
+	var reviews []domain.PendingReview
+	err := db.Model(&domain.PRReviewer{}).
+		Select("pr_reviewers.pull_request_id, pull_requests.author_id, pull_requests.team_name, pr_reviewers.reviewer_id, pr_reviewers.assigned_at").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pr_reviewers.pull_request_id").
+		Where("pull_requests.status = ? AND pr_reviewers.state = ? AND pr_reviewers.escalated_at IS NULL", domain.PRStatusOpen, domain.ReviewStatePending).
+		Order("pr_reviewers.pull_request_id").
+		Find(&reviews).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *PostgresRepository) MarkReviewEscalated(ctx context.Context, prID, userID string, at time.Time) error {
+	db := r.getDB(ctx)
+	result := db.Model(&domain.PRReviewer{}).
+		Where("pull_request_id = ? AND reviewer_id = ?", prID, userID).
+		Update("escalated_at", at)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReviewerNotAssigned
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) ResetReviewerAssignments(ctx context.Context, prID string, at time.Time) error {
+	db := r.getDB(ctx)
+
+	return db.Model(&domain.PRReviewer{}).
+		Where("pull_request_id = ?", prID).
+		Updates(map[string]interface{}{
+			"assigned_at":  at,
+			"escalated_at": nil,
+		}).Error
+}
+
+func (r *PostgresRepository) GetReviewPairings(ctx context.Context, authorID string, since time.Time) ([]domain.ReviewPairing, error) {
+	db := r.getDB(ctx)
+
+	var pairings []domain.ReviewPairing
+	err := db.Model(&domain.AssignmentRecord{}).
+		Select("assignment_records.reviewer_id, assignment_records.assigned_at AS paired_at").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = assignment_records.pull_request_id").
+		Where("pull_requests.author_id = ? AND assignment_records.assigned_at >= ?", authorID, since).
+		Find(&pairings).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pairings, nil
+}
+
 func (r *PostgresRepository) DeactivateUsers(ctx context.Context, userIDs []string) error {
 	db := r.getDB(ctx)
 
@@ -356,7 +744,7 @@ func (r *PostgresRepository) GetOpenPRsWithReviewers(ctx context.Context, review
 	return prs, reviewersMap, nil
 }
 
-func (r *PostgresRepository) BulkReassignReviewers(ctx context.Context, reassignments []domain.PRReassignment) error {
+func (r *PostgresRepository) BulkReassignReviewers(ctx context.Context, reassignments []domain.PRReassignment, at time.Time) error {
 	db := r.getDB(ctx)
 
 	for _, reassignment := range reassignments {
@@ -379,6 +767,8 @@ func (r *PostgresRepository) BulkReassignReviewers(ctx context.Context, reassign
 				newPRReviewer := domain.PRReviewer{
 					PullRequestID: reassignment.PullRequestID,
 					ReviewerID:    reassignment.NewReviewerID,
+					State:         domain.ReviewStatePending,
+					AssignedAt:    at,
 				}
 				if err := db.Create(&newPRReviewer).Error; err != nil {
 					return domain.NewDatabaseError("add new reviewer", err)
@@ -390,6 +780,152 @@ func (r *PostgresRepository) BulkReassignReviewers(ctx context.Context, reassign
 	return nil
 }
 
+func (r *PostgresRepository) ReplaceCodeOwnerRules(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string, rules []domain.CodeOwnerRule) error {
+	db := r.getDB(ctx)
+
+	if err := db.Where("scope_type = ? AND scope_name = ?", scopeType, scopeName).
+		Delete(&domain.CodeOwnerRule{}).Error; err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return db.Create(&rules).Error
+}
+
+func (r *PostgresRepository) GetCodeOwnerRules(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string) ([]domain.CodeOwnerRule, error) {
+	db := r.getDB(ctx)
+
+	var rules []domain.CodeOwnerRule
+	if err := db.Where("scope_type = ? AND scope_name = ?", scopeType, scopeName).
+		Order("position").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *PostgresRepository) SaveAssignmentRecords(ctx context.Context, records []domain.AssignmentRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	db := r.getDB(ctx)
+	return db.Create(&records).Error
+}
+
+func (r *PostgresRepository) GetAssignmentRecords(ctx context.Context, prID string) ([]domain.AssignmentRecord, error) {
+	db := r.getDB(ctx)
+
+	var records []domain.AssignmentRecord
+	if err := db.Where("pull_request_id = ?", prID).Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (r *PostgresRepository) AddPREvent(ctx context.Context, event *domain.PREvent) error {
+	db := r.getDB(ctx)
+	return db.Create(event).Error
+}
+
+func (r *PostgresRepository) GetPREvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	db := r.getDB(ctx)
+
+	var events []domain.PREvent
+	if err := db.Where("pull_request_id = ?", prID).Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *PostgresRepository) CreateOutOfOffice(ctx context.Context, period *domain.OutOfOffice) error {
+	db := r.getDB(ctx)
+	return db.Create(period).Error
+}
+
+func (r *PostgresRepository) GetUserOutOfOffice(ctx context.Context, userID string) ([]domain.OutOfOffice, error) {
+	db := r.getDB(ctx)
+
+	var periods []domain.OutOfOffice
+	if err := db.Where("user_id = ?", userID).Order("starts_at").Find(&periods).Error; err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *PostgresRepository) DeleteOutOfOffice(ctx context.Context, id uint) error {
+	db := r.getDB(ctx)
+	result := db.Delete(&domain.OutOfOffice{}, id)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrOutOfOfficeNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresRepository) GetOutOfOfficeUserIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	db := r.getDB(ctx)
+
+	absent := make(map[string]bool)
+	if len(userIDs) == 0 {
+		return absent, nil
+	}
+
+	var ids []string
+	if err := db.Model(&domain.OutOfOffice{}).
+		Distinct("user_id").
+		Where("user_id IN ? AND starts_at <= ? AND ends_at > ?", userIDs, at, at).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		absent[id] = true
+	}
+
+	return absent, nil
+}
+
+func (r *PostgresRepository) GetPendingOutOfOfficeReassignments(ctx context.Context, at time.Time) ([]domain.OutOfOffice, error) {
+	db := r.getDB(ctx)
+
+	var periods []domain.OutOfOffice
+	if err := db.Where("reassign_reviews = ? AND reviews_reassigned = ? AND starts_at <= ? AND ends_at > ?", true, false, at, at).
+		Order("id").
+		Find(&periods).Error; err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (r *PostgresRepository) MarkOutOfOfficeReassigned(ctx context.Context, id uint) error {
+	db := r.getDB(ctx)
+	result := db.Model(&domain.OutOfOffice{}).Where("id = ?", id).Update("reviews_reassigned", true)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrOutOfOfficeNotFound
+	}
+
+	return nil
+}
+
 func (r *PostgresRepository) GetAssignmentStats(ctx context.Context) (map[string]int, error) {
 	db := r.getDB(ctx)
 
@@ -414,3 +950,34 @@ func (r *PostgresRepository) GetAssignmentStats(ctx context.Context) (map[string
 
 	return stats, nil
 }
+
+func (r *PostgresRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	db := r.getDB(ctx)
+
+	var results []struct {
+		ReviewerID string
+		Count      int
+	}
+
+	err := db.Model(&domain.PRReviewer{}).
+		Select("pr_reviewers.reviewer_id, COUNT(*) as count").
+		Joins("JOIN pull_requests ON pull_requests.pull_request_id = pr_reviewers.pull_request_id").
+		Where("pull_requests.status = ? AND pr_reviewers.reviewer_id IN ?", domain.PRStatusOpen, userIDs).
+		Group("pr_reviewers.reviewer_id").
+		Find(&results).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		counts[result.ReviewerID] = result.Count
+	}
+
+	return counts, nil
+}
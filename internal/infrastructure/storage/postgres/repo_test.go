@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"pr-reviewer/internal/domain"
+)
+
+// Тесты запускаются только при заданном TEST_POSTGRES_DSN и пересоздают все таблицы,
+// поэтому DSN должен указывать на отдельную тестовую базу
+func testDSN(t *testing.T) string {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	return dsn
+}
+
+// Схема до появления членства в командах: users.team_name ссылается на teams
+type legacyUser struct {
+	UserID   string `gorm:"primaryKey"`
+	Username string `gorm:"not null"`
+	TeamName string `gorm:"index;not null"`
+	IsActive bool   `gorm:"default:true"`
+}
+
+func (legacyUser) TableName() string { return "users" }
+
+type legacyTeam struct {
+	TeamName string       `gorm:"primaryKey"`
+	Members  []legacyUser `gorm:"foreignKey:TeamName;references:TeamName"`
+}
+
+func (legacyTeam) TableName() string { return "teams" }
+
+func resetSchema(t *testing.T, dsn string, models ...interface{}) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Exec(`DROP TABLE IF EXISTS teams, users, team_memberships, pull_requests, pr_reviewers,
+		team_settings, code_owner_rules, out_of_offices, assignment_records, pr_events CASCADE`).Error)
+	if len(models) > 0 {
+		require.NoError(t, db.AutoMigrate(models...))
+	}
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+}
+
+func TestPostgresRepository_RenameTeam_LegacySchema(t *testing.T) {
+	dsn := testDSN(t)
+	resetSchema(t, dsn, &legacyTeam{}, &legacyUser{})
+
+	repo, err := NewPostgresRepository(dsn)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ctx := context.Background()
+	tx := NewGormTransactionManager(repo.GetDB())
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "API", AuthorID: "u1", TeamName: "backend", Status: domain.PRStatusOpen}, nil))
+
+	err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return repo.RenameTeam(ctx, "backend", "core")
+	})
+	require.NoError(t, err)
+
+	team, err := repo.GetTeam(ctx, "core")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 1)
+
+	user, err := repo.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "core", user.TeamName)
+
+	pr, err := repo.GetPR(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "core", pr.TeamName)
+
+	assert.Equal(t, domain.ErrTeamNotFound, repo.RenameTeam(ctx, "backend", "other"))
+}
+
+func TestPostgresRepository_ReviewersOrderedByAssignment(t *testing.T) {
+	dsn := testDSN(t)
+	resetSchema(t, dsn)
+
+	repo, err := NewPostgresRepository(dsn)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ctx := context.Background()
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+	}))
+
+	createdAt := time.Now().Add(-time.Hour)
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "API", AuthorID: "u1", TeamName: "backend", Status: domain.PRStatusOpen, CreatedAt: &createdAt}, []string{"u3", "u2"}))
+	require.NoError(t, repo.AddReviewer(ctx, "pr-1", "u4", createdAt.Add(-time.Minute)))
+
+	states, err := repo.GetPRReviewerStates(ctx, "pr-1")
+	require.NoError(t, err)
+	reviewerIDs := make([]string, len(states))
+	for i, state := range states {
+		reviewerIDs[i] = state.ReviewerID
+	}
+	assert.Equal(t, []string{"u4", "u2", "u3"}, reviewerIDs)
+
+	reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, reviewerIDs, reviewers)
+}
@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"time"
+
 	"pr-reviewer/internal/domain"
 )
 
@@ -10,33 +12,90 @@ type Repository interface {
 	CreateTeam(ctx context.Context, team *domain.Team, members []domain.User) error
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
 	TeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	SaveTeamSettings(ctx context.Context, settings *domain.TeamSettings) error
+	// ListTeams возвращает все команды без участников, упорядоченные по имени
+	ListTeams(ctx context.Context) ([]domain.Team, error)
+	SetTeamParent(ctx context.Context, teamName, parentTeam string) error
+	// RenameTeam меняет имя команды в ней самой, ее настройках, членстве, основной команде
+	// пользователей, PR, дочерних командах и правилах владения кодом.
+	// Ссылки из настроек других команд не затрагиваются.
+	RenameTeam(ctx context.Context, oldName, newName string) error
+	// DeleteTeam удаляет команду вместе с настройками, членством и правилами владения кодом
+	DeleteTeam(ctx context.Context, teamName string) error
+	// MoveTeamPRs переводит все PR команды в toTeam; пустая toTeam отвязывает PR от команды
+	MoveTeamPRs(ctx context.Context, fromTeam, toTeam string) error
+
+	// Membership: пользователь может состоять в нескольких командах,
+	// GetTeam, GetUsersByTeam и GetActiveTeamMembers возвращают участников по членству
+	AddTeamMember(ctx context.Context, teamName, userID string) error
+	RemoveTeamMember(ctx context.Context, teamName, userID string) error
+	GetUserTeams(ctx context.Context, userID string) ([]string, error)
 
 	// User
 	CreateOrUpdateUser(ctx context.Context, user *domain.User) error
 	GetUser(ctx context.Context, userID string) (*domain.User, error)
 	GetUsersByTeam(ctx context.Context, teamName string) ([]domain.User, error)
 	SetUserActive(ctx context.Context, userID string, isActive bool) error
+	SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error
+	SetUserSkills(ctx context.Context, userID string, skills []string) error
+	SetUserLevel(ctx context.Context, userID string, level domain.UserLevel) error
+	// SetUserTeam меняет основную команду пользователя, членство не затрагивается
+	SetUserTeam(ctx context.Context, userID string, teamName string) error
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUserID string) ([]domain.User, error)
 
 	// PR
+	// CreatePR сохраняет PR; время назначения ревьюверов берется из pr.CreatedAt
 	CreatePR(ctx context.Context, pr *domain.PullRequest, reviewers []string) error
 	GetPR(ctx context.Context, prID string) (*domain.PullRequest, error)
 	GetPRWithReviewers(ctx context.Context, prID string) (*domain.PullRequest, []string, error)
 	PRExists(ctx context.Context, prID string) (bool, error)
 	MergePR(ctx context.Context, prID string) error
+	SetPRStatus(ctx context.Context, prID string, status domain.PRStatus) error
+	// UpdatePR сохраняет название, автора и метки PR
+	UpdatePR(ctx context.Context, pr *domain.PullRequest) error
 
 	// PR Reviewer
 	GetPRReviewers(ctx context.Context, prID string) ([]string, error)
-	AddReviewer(ctx context.Context, prID, userID string) error
+	AddReviewer(ctx context.Context, prID, userID string, at time.Time) error
 	RemoveReviewer(ctx context.Context, prID, userID string) error
 	GetUserReviews(ctx context.Context, userID string) ([]domain.PullRequest, error)
 	IsReviewerAssigned(ctx context.Context, prID, userID string) (bool, error)
+	GetPRReviewerStates(ctx context.Context, prID string) ([]domain.PRReviewer, error)
+	SubmitReview(ctx context.Context, prID, userID string, state domain.ReviewState, at time.Time) error
+	GetPendingReviews(ctx context.Context) ([]domain.PendingReview, error)
+	MarkReviewEscalated(ctx context.Context, prID, userID string, at time.Time) error
+	// ResetReviewerAssignments переносит время назначения ревьюверов PR на at и снимает отметки эскалации
+	ResetReviewerAssignments(ctx context.Context, prID string, at time.Time) error
+	GetReviewPairings(ctx context.Context, authorID string, since time.Time) ([]domain.ReviewPairing, error)
 
 	// Mass deactivate
 	DeactivateUsers(ctx context.Context, userIDs []string) error
+	// Открытая нагрузка учитывает только PR в статусе OPEN: у DRAFT нет ревьюверов, CLOSED их освобождает
 	GetOpenPRsWithReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequest, map[string][]string, error)
-	BulkReassignReviewers(ctx context.Context, reassignments []domain.PRReassignment) error
+	BulkReassignReviewers(ctx context.Context, reassignments []domain.PRReassignment, at time.Time) error
+
+	// Code owners
+	ReplaceCodeOwnerRules(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string, rules []domain.CodeOwnerRule) error
+	GetCodeOwnerRules(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string) ([]domain.CodeOwnerRule, error)
+
+	// PR history
+	AddPREvent(ctx context.Context, event *domain.PREvent) error
+	GetPREvents(ctx context.Context, prID string) ([]domain.PREvent, error)
+
+	// Assignment trace
+	SaveAssignmentRecords(ctx context.Context, records []domain.AssignmentRecord) error
+	GetAssignmentRecords(ctx context.Context, prID string) ([]domain.AssignmentRecord, error)
+
+	// Out of office
+	CreateOutOfOffice(ctx context.Context, period *domain.OutOfOffice) error
+	GetUserOutOfOffice(ctx context.Context, userID string) ([]domain.OutOfOffice, error)
+	DeleteOutOfOffice(ctx context.Context, id uint) error
+	GetOutOfOfficeUserIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error)
+	GetPendingOutOfOfficeReassignments(ctx context.Context, at time.Time) ([]domain.OutOfOffice, error)
+	MarkOutOfOfficeReassigned(ctx context.Context, id uint) error
 
 	// Statistics
 	GetAssignmentStats(ctx context.Context) (map[string]int, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
 }
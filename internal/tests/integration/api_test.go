@@ -49,20 +49,26 @@ func setupTestServer(t *testing.T) *httpInfra.Server {
 	authenticator := auth.NewStaticTokenAuth(cfg.Auth.AdminToken, cfg.Auth.UserToken)
 	txManager := &NoOpTransactionManager{}
 
-	teamService := usecase.NewTeamService(repo, txManager, appLogger)
+	assigner, err := usecase.NewReviewerAssigner(repo, usecase.NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer)
+	require.NoError(t, err)
+
+	teamService := usecase.NewTeamService(repo, txManager, assigner, appLogger)
 	userService := usecase.NewUserService(repo, txManager, appLogger)
-	prService := usecase.NewPRService(repo, txManager, appLogger)
+	prService := usecase.NewPRService(repo, txManager, assigner, appLogger)
+	codeOwnersService := usecase.NewCodeOwnersService(repo, txManager, appLogger)
 	metricsService := usecase.NewMetricsService(repo, txManager, appLogger)
 
 	teamHandler := handlers.NewTeamHandler(teamService, appLogger)
 	userHandler := handlers.NewUserHandler(userService, appLogger)
 	prHandler := handlers.NewPRHandler(prService, appLogger)
+	codeOwnersHandler := handlers.NewCodeOwnersHandler(codeOwnersService, appLogger)
 
 	return httpInfra.NewServer(
 		cfg,
 		teamHandler,
 		userHandler,
 		prHandler,
+		codeOwnersHandler,
 		metricsService,
 		authenticator,
 		metricsCollector,
@@ -115,8 +121,8 @@ func TestIntegration_CreateTeamAndPR(t *testing.T) {
 
 	assert.Equal(t, "pr-001", response.PR.PullRequestID)
 	assert.Equal(t, domain.PRStatusOpen, response.PR.Status)
-	assert.LessOrEqual(t, len(response.PR.AssignedReviewers), 2)
-	assert.NotContains(t, response.PR.AssignedReviewers, "u1")
+	assert.LessOrEqual(t, len(response.PR.ReviewerIDs()), 2)
+	assert.NotContains(t, response.PR.ReviewerIDs(), "u1")
 }
 
 func TestIntegration_FullWorkflow(t *testing.T) {
@@ -158,10 +164,10 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	}
 	json.NewDecoder(w.Body).Decode(&createResp)
 
-	if len(createResp.PR.AssignedReviewers) > 0 {
+	if len(createResp.PR.ReviewerIDs()) > 0 {
 		reassignReq := domain.ReassignRequest{
 			PullRequestID: "pr-workflow",
-			OldUserID:     createResp.PR.AssignedReviewers[0],
+			OldUserID:     createResp.PR.ReviewerIDs()[0],
 		}
 
 		body, _ = json.Marshal(reassignReq)
@@ -173,6 +179,28 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	}
 
+	if len(createResp.PR.ReviewerIDs()) > 1 {
+		reviewReq := domain.SubmitReviewRequest{
+			PullRequestID: "pr-workflow",
+			ReviewerID:    createResp.PR.ReviewerIDs()[1],
+			State:         domain.ReviewStateApproved,
+		}
+
+		body, _ = json.Marshal(reviewReq)
+		req = httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "test-user-token")
+		w = httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var reviewResp struct {
+			PR domain.PullRequestResponse `json:"pr"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&reviewResp))
+		assert.Contains(t, reviewResp.PR.ReviewerIDs(), reviewReq.ReviewerID)
+	}
+
 	mergeReq := domain.MergePRRequest{
 		PullRequestID: "pr-workflow",
 	}
@@ -193,6 +221,96 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	assert.NotNil(t, mergeResp.PR.MergedAt)
 }
 
+func TestIntegration_DryRun(t *testing.T) {
+	server := setupTestServer(t)
+
+	teamReq := domain.CreateTeamRequest{
+		TeamName: "mobile",
+		Members: []domain.TeamMember{
+			{UserID: "m1", Username: "MobAlice", IsActive: true},
+			{UserID: "m2", Username: "MobBob", IsActive: true},
+			{UserID: "m3", Username: "MobCharlie", IsActive: true},
+			{UserID: "m4", Username: "MobDavid", IsActive: false},
+		},
+	}
+
+	body, _ := json.Marshal(teamReq)
+	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	prReq := domain.CreatePRRequest{
+		PullRequestID:   "pr-preview",
+		PullRequestName: "New onboarding",
+		AuthorID:        "m1",
+	}
+
+	body, _ = json.Marshal(prReq)
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/preview", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "test-admin-token")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var previewResp struct {
+		PR     domain.PullRequestResponse `json:"pr"`
+		DryRun bool                       `json:"dry_run"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&previewResp))
+	assert.True(t, previewResp.DryRun)
+	assert.ElementsMatch(t, []string{"m2", "m3"}, previewResp.PR.ReviewerIDs())
+
+	// Превью не создает PR, поэтому создание с тем же ID проходит
+	body, _ = json.Marshal(prReq)
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "test-admin-token")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// Активируем m4, чтобы для переназначения нашелся кандидат
+	body, _ = json.Marshal(domain.SetIsActiveRequest{UserID: "m4", IsActive: true})
+	req = httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "test-admin-token")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	reassignReq := domain.ReassignRequest{
+		PullRequestID: "pr-preview",
+		OldUserID:     "m2",
+	}
+
+	body, _ = json.Marshal(reassignReq)
+	req = httptest.NewRequest(http.MethodPost, "/pullRequest/reassign?dry_run=true", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "test-admin-token")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var reassignResp domain.ReassignResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reassignResp))
+	assert.Equal(t, "m4", reassignResp.ReplacedBy)
+	assert.ElementsMatch(t, []string{"m3", "m4"}, reassignResp.PR.ReviewerIDs())
+
+	// После dry run ревьюверы PR не изменились
+	req = httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=m2", nil)
+	req.Header.Set("Authorization", "test-user-token")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var reviewsResp domain.UserReviewsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reviewsResp))
+	assert.Len(t, reviewsResp.PullRequests, 1)
+}
+
 func TestIntegration_Authentication(t *testing.T) {
 	server := setupTestServer(t)
 
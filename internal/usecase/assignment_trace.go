@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+// Трасса назначения собирает записи о выбранных ревьюверах одного PR.
+// Если трасса не включена в ctx, выбор ревьюверов ничего не записывает.
+type assignmentTraceKey struct{}
+
+type assignmentTrace struct {
+	prID     string
+	authorID string
+	assigned map[string]bool
+	excluded map[string]bool
+	records  []domain.AssignmentRecord
+}
+
+func withAssignmentTrace(ctx context.Context, prID, authorID string, assigned []string) context.Context {
+	trace := &assignmentTrace{
+		prID:     prID,
+		authorID: authorID,
+		assigned: make(map[string]bool, len(assigned)),
+		excluded: make(map[string]bool),
+	}
+	for _, userID := range assigned {
+		trace.assigned[userID] = true
+	}
+
+	return context.WithValue(ctx, assignmentTraceKey{}, trace)
+}
+
+func traceFromContext(ctx context.Context) *assignmentTrace {
+	trace, _ := ctx.Value(assignmentTraceKey{}).(*assignmentTrace)
+	return trace
+}
+
+// markExcluded запоминает пользователей, которых автор PR исключил явно
+func (t *assignmentTrace) markExcluded(userIDs []string) {
+	for _, userID := range userIDs {
+		t.excluded[userID] = true
+	}
+}
+
+// traceRecords возвращает записи только для тех, кто в итоге попал в reviewerIDs
+func traceRecords(ctx context.Context, reviewerIDs []string) []domain.AssignmentRecord {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return nil
+	}
+
+	kept := make(map[string]bool, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		kept[id] = true
+	}
+
+	records := make([]domain.AssignmentRecord, 0, len(reviewerIDs))
+	for _, record := range trace.records {
+		if kept[record.ReviewerID] {
+			records = append(records, record)
+			delete(kept, record.ReviewerID)
+		}
+	}
+
+	return records
+}
+
+// selectionStep описывает один вызов стратегии выбора
+type selectionStep struct {
+	source     domain.AssignmentSource
+	teamName   string
+	membersOf  string
+	candidates []domain.User
+	pool       []domain.User
+	absent     []domain.User
+	atCapacity []domain.User
+	selected   []domain.User
+}
+
+func (t *assignmentTrace) add(step selectionStep, strategy domain.SelectionStrategy, excludedByID map[string]domain.ExclusionReason, at time.Time) {
+	excluded := make([]domain.ExcludedCandidate, 0, len(excludedByID))
+	for userID, reason := range excludedByID {
+		excluded = append(excluded, domain.ExcludedCandidate{UserID: userID, Reason: reason})
+	}
+	sort.Slice(excluded, func(i, j int) bool {
+		return excluded[i].UserID < excluded[j].UserID
+	})
+
+	for _, u := range step.selected {
+		t.records = append(t.records, domain.AssignmentRecord{
+			PullRequestID:     t.prID,
+			ReviewerID:        u.UserID,
+			Source:            step.source,
+			Strategy:          strategy,
+			CandidatePoolSize: len(step.pool),
+			Excluded:          excluded,
+			AssignedAt:        at,
+		})
+		t.assigned[u.UserID] = true
+	}
+}
+
+// exclusionReason объясняет, почему участник команды не попал в число кандидатов
+func (t *assignmentTrace) exclusionReason(member domain.User) domain.ExclusionReason {
+	switch {
+	case member.UserID == t.authorID:
+		return domain.ExclusionAuthor
+	case t.excluded[member.UserID]:
+		return domain.ExclusionExcluded
+	case !member.IsActive:
+		return domain.ExclusionInactive
+	case t.assigned[member.UserID]:
+		return domain.ExclusionAlreadyAssigned
+	default:
+		return domain.ExclusionNotEligible
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"pr-reviewer/internal/domain"
+)
+
+// parseCodeOwners разбирает файл в формате CODEOWNERS: "<pattern> <owner> [<owner>...]".
+// Пустые строки и комментарии пропускаются, префикс "@" у владельцев отбрасывается.
+// Шаблон без владельцев допустим: он снимает владельцев, назначенных файлам правилами выше.
+func parseCodeOwners(scopeType domain.CodeOwnerScope, scopeName, content string) ([]domain.CodeOwnerRule, error) {
+	rules := make([]domain.CodeOwnerRule, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if _, err := compileCodeOwnerPattern(fields[0]); err != nil {
+			return nil, domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("line %d: invalid pattern %q", lineNum, fields[0]))
+		}
+
+		owners := make([]string, 0, len(fields)-1)
+		for _, owner := range fields[1:] {
+			owners = append(owners, strings.TrimPrefix(owner, "@"))
+		}
+
+		rules = append(rules, domain.CodeOwnerRule{
+			ScopeType: scopeType,
+			ScopeName: scopeName,
+			Position:  len(rules),
+			Pattern:   fields[0],
+			Owners:    owners,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("failed to read code owners: %v", err))
+	}
+
+	return rules, nil
+}
+
+// compileCodeOwnerPattern переводит glob-шаблон CODEOWNERS в регулярное выражение.
+// Шаблон без "/" в середине совпадает на любой глубине, "/" в конце означает каталог целиком.
+func compileCodeOwnerPattern(pattern string) (*regexp.Regexp, error) {
+	p := strings.TrimPrefix(pattern, "/")
+	anchored := p != pattern || strings.Contains(strings.TrimSuffix(p, "/"), "/")
+	p = strings.TrimSuffix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				if i+2 < len(p) && p[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	// Совпадение с каталогом захватывает все вложенные файлы
+	b.WriteString("(?:/.*)?$")
+
+	return regexp.Compile(b.String())
+}
+
+// matchCodeOwners возвращает владельцев для каждого файла по последнему совпавшему правилу.
+// Если это правило без владельцев, файл остается в результате с пустым списком.
+func matchCodeOwners(rules []domain.CodeOwnerRule, files []string) map[string][]string {
+	compiled := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		// Правила валидируются при загрузке, поэтому ошибки здесь не ожидаются
+		compiled[i], _ = compileCodeOwnerPattern(rule.Pattern)
+	}
+
+	owners := make(map[string][]string, len(files))
+	for _, file := range files {
+		path := strings.TrimPrefix(strings.TrimPrefix(file, "./"), "/")
+		for i := len(rules) - 1; i >= 0; i-- {
+			if compiled[i] != nil && compiled[i].MatchString(path) {
+				owners[file] = rules[i].Owners
+				break
+			}
+		}
+	}
+
+	return owners
+}
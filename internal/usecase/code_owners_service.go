@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/logger"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+type CodeOwnersService struct {
+	repo   storage.Repository
+	tx     domain.TransactionManager
+	logger logger.Logger
+}
+
+func NewCodeOwnersService(repo storage.Repository, tx domain.TransactionManager, logger logger.Logger) *CodeOwnersService {
+	return &CodeOwnersService{
+		repo:   repo,
+		tx:     tx,
+		logger: logger,
+	}
+}
+
+func (s *CodeOwnersService) UploadCodeOwners(ctx context.Context, req domain.UploadCodeOwnersRequest) (*domain.CodeOwnersResponse, error) {
+	var result *domain.CodeOwnersResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.validateScope(ctx, req.ScopeType, req.ScopeName); err != nil {
+			return err
+		}
+
+		rules, err := parseCodeOwners(req.ScopeType, req.ScopeName, req.Content)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.ReplaceCodeOwnerRules(ctx, req.ScopeType, req.ScopeName, rules); err != nil {
+			s.logger.Error("Failed to save code owner rules", "error", err)
+			return err
+		}
+
+		result = &domain.CodeOwnersResponse{
+			ScopeType: req.ScopeType,
+			ScopeName: req.ScopeName,
+			Rules:     rules,
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (s *CodeOwnersService) GetCodeOwners(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string) (*domain.CodeOwnersResponse, error) {
+	if err := s.validateScope(ctx, scopeType, scopeName); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repo.GetCodeOwnerRules(ctx, scopeType, scopeName)
+	if err != nil {
+		s.logger.Error("Failed to get code owner rules", "error", err)
+		return nil, err
+	}
+
+	return &domain.CodeOwnersResponse{
+		ScopeType: scopeType,
+		ScopeName: scopeName,
+		Rules:     rules,
+	}, nil
+}
+
+func (s *CodeOwnersService) validateScope(ctx context.Context, scopeType domain.CodeOwnerScope, scopeName string) error {
+	switch scopeType {
+	case domain.CodeOwnerScopeTeam:
+		if _, err := s.repo.GetTeam(ctx, scopeName); err != nil {
+			return err
+		}
+	case domain.CodeOwnerScopeRepository:
+		if scopeName == "" {
+			return domain.NewAppError(domain.ErrCodeBadRequest, "scope_name is required")
+		}
+	default:
+		return domain.ErrInvalidCodeOwnerScope
+	}
+
+	return nil
+}
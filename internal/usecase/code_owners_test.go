@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer/internal/domain"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	content := `
+# Владельцы по умолчанию
+*           @u1
+/db/        @u2 @u3   # миграции
+*.sql       u3
+/docs/      # без владельцев
+`
+
+	rules, err := parseCodeOwners(domain.CodeOwnerScopeTeam, "backend", content)
+	require.NoError(t, err)
+	require.Len(t, rules, 4)
+	assert.Equal(t, "/db/", rules[1].Pattern)
+	assert.Equal(t, []string{"u2", "u3"}, rules[1].Owners)
+	assert.Equal(t, 2, rules[2].Position)
+	assert.Equal(t, "/docs/", rules[3].Pattern)
+	assert.Empty(t, rules[3].Owners)
+
+	_, err = parseCodeOwners(domain.CodeOwnerScopeTeam, "backend", strings.Repeat("a", bufio.MaxScanTokenSize)+" @u1")
+	assert.Error(t, err)
+}
+
+func TestCompileCodeOwnerPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*", "cmd/app/main.go", true},
+		{"*.go", "internal/usecase/pr_service.go", true},
+		{"*.go", "README.md", false},
+		{"/db/", "db/migrations/001.sql", true},
+		{"/db/", "internal/db/conn.go", false},
+		{"db/", "internal/db/conn.go", true},
+		{"docs/*.md", "docs/api.md", true},
+		{"docs/*.md", "docs/v1/api.md", false},
+		{"docs/**/*.md", "docs/v1/api.md", true},
+		{"internal/usecase", "internal/usecase/pr_service.go", true},
+	}
+
+	for _, tc := range cases {
+		re, err := compileCodeOwnerPattern(tc.pattern)
+		require.NoError(t, err)
+		assert.Equal(t, tc.match, re.MatchString(tc.path), "%s ~ %s", tc.pattern, tc.path)
+	}
+}
+
+func TestMatchCodeOwners_LastRuleWins(t *testing.T) {
+	rules := []domain.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"u1"}},
+		{Pattern: "*.sql", Owners: []string{"u3"}},
+	}
+
+	owners := matchCodeOwners(rules, []string{"main.go", "db/001.sql"})
+	assert.Equal(t, []string{"u1"}, owners["main.go"])
+	assert.Equal(t, []string{"u3"}, owners["db/001.sql"])
+}
+
+func TestMatchCodeOwners_RuleWithoutOwnersUnsetsMatch(t *testing.T) {
+	rules := []domain.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"u1"}},
+		{Pattern: "/docs/", Owners: []string{}},
+	}
+
+	owners := matchCodeOwners(rules, []string{"main.go", "docs/api.md"})
+	assert.Equal(t, []string{"u1"}, owners["main.go"])
+	fileOwners, matched := owners["docs/api.md"]
+	assert.True(t, matched)
+	assert.Empty(t, fileOwners)
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import "context"
+
+// В режиме dry run выбор ревьюверов не должен менять ни хранилище, ни состояние стратегий
+type dryRunKey struct{}
+
+func withDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
@@ -21,14 +21,54 @@ func NewMetricsService(repo storage.Repository, tx domain.TransactionManager, lo
 	}
 }
 
-func (s *MetricsService) GetAssignmentStats(ctx context.Context) (map[string]interface{}, error) {
+// GetAssignmentStats возвращает число назначений по ревьюверам. Если задан teamName,
+// учитываются только участники команды, а при subtree — участники всего ее поддерева.
+func (s *MetricsService) GetAssignmentStats(ctx context.Context, teamName string, subtree bool) (map[string]interface{}, error) {
 	stats, err := s.repo.GetAssignmentStats(ctx)
 	if err != nil {
 		s.logger.Error("Failed to get assignment stats", "error", err)
 		return nil, err
 	}
 
+	if teamName == "" {
+		return map[string]interface{}{
+			"reviewer_assignments": stats,
+		}, nil
+	}
+
+	if _, err := s.repo.GetTeam(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	teams := []string{teamName}
+	if subtree {
+		hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+		if err != nil {
+			s.logger.Error("Failed to load team hierarchy", "error", err)
+			return nil, err
+		}
+		teams = hierarchy.subtree(teamName)
+	}
+
+	members, err := subtreeMembers(ctx, s.repo, teams, false)
+	if err != nil {
+		s.logger.Error("Failed to get team members", "error", err)
+		return nil, err
+	}
+
+	filtered := make(map[string]int, len(members))
+	total := 0
+	for _, m := range members {
+		if count := stats[m.UserID]; count > 0 {
+			filtered[m.UserID] = count
+			total += count
+		}
+	}
+
 	return map[string]interface{}{
-		"reviewer_assignments": stats,
+		"team_name":            teamName,
+		"teams":                teams,
+		"total_assignments":    total,
+		"reviewer_assignments": filtered,
 	}, nil
 }
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer/internal/infrastructure/logger"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// OutOfOfficeJob периодически снимает с открытых PR пользователей, чье отсутствие началось.
+// Обрабатываются только периоды с флагом reassign_reviews, каждый не более одного раза.
+type OutOfOfficeJob struct {
+	repo     storage.Repository
+	teams    *TeamService
+	interval time.Duration
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+func NewOutOfOfficeJob(repo storage.Repository, teams *TeamService, interval time.Duration, logger logger.Logger) *OutOfOfficeJob {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &OutOfOfficeJob{
+		repo:     repo,
+		teams:    teams,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Run выполняет проверку сразу и затем с заданным интервалом до отмены ctx
+func (j *OutOfOfficeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.RunOnce(ctx); err != nil {
+			j.logger.Error("Out of office job failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *OutOfOfficeJob) RunOnce(ctx context.Context) error {
+	periods, err := j.repo.GetPendingOutOfOfficeReassignments(ctx, j.now())
+	if err != nil {
+		return err
+	}
+
+	for _, period := range periods {
+		summaries, err := j.teams.ReassignOpenReviews(ctx, []string{period.UserID})
+		if err != nil {
+			j.logger.Error("Failed to reassign reviews of absent user", "user_id", period.UserID, "error", err)
+			continue
+		}
+
+		if err := j.repo.MarkOutOfOfficeReassigned(ctx, period.ID); err != nil {
+			j.logger.Error("Failed to mark out of office period", "id", period.ID, "error", err)
+			continue
+		}
+
+		j.logger.Info("Reassigned reviews of absent user", "user_id", period.UserID, "prs", len(summaries))
+	}
+
+	return nil
+}
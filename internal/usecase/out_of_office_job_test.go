@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage/memory"
+)
+
+func setupOutOfOfficeRepo(t *testing.T) *memory.MemoryRepository {
+	repo := memory.NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}))
+
+	return repo
+}
+
+func TestReviewerAssigner_SkipsOutOfOffice(t *testing.T) {
+	repo := setupOutOfOfficeRepo(t)
+	ctx := context.Background()
+	now := time.Now()
+	assigner := newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer)
+
+	require.NoError(t, repo.CreateOutOfOffice(ctx, &domain.OutOfOffice{UserID: "u2", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}))
+	require.NoError(t, repo.CreateOutOfOffice(ctx, &domain.OutOfOffice{UserID: "u3", StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)}))
+
+	candidates, err := repo.GetActiveTeamMembers(ctx, "backend", "u1")
+	require.NoError(t, err)
+
+	selected, err := assigner.selectReviewers(ctx, "", domain.AssignmentSourceTeam, "backend", candidates, 2)
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+	assert.Equal(t, "u3", selected[0].UserID)
+}
+
+func TestOutOfOfficeJob_RunOnce(t *testing.T) {
+	repo := setupOutOfOfficeRepo(t)
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+	now := time.Now()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	teamService := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+	job := NewOutOfOfficeJob(repo, teamService, time.Minute, mockLogger)
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2"}))
+	period := &domain.OutOfOffice{UserID: "u2", StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), ReassignReviews: true}
+	require.NoError(t, repo.CreateOutOfOffice(ctx, period))
+
+	require.NoError(t, job.RunOnce(ctx))
+
+	reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, reviewers)
+
+	user, err := repo.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	periods, err := repo.GetUserOutOfOffice(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+	assert.True(t, periods[0].ReviewsReassigned)
+}
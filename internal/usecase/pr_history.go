@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// addPREvents дописывает события в историю PR; вызывается в той же транзакции, что и само изменение
+func addPREvents(ctx context.Context, repo storage.Repository, events []domain.PREvent) error {
+	for i := range events {
+		if err := repo.AddPREvent(ctx, &events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func assignedEvents(prID string, reviewerIDs []string, at time.Time) []domain.PREvent {
+	events := make([]domain.PREvent, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		events[i] = domain.PREvent{
+			PullRequestID: prID,
+			Type:          domain.PREventReviewerAssigned,
+			ReviewerID:    reviewerID,
+			CreatedAt:     at,
+		}
+	}
+	return events
+}
+
+// reassignedEvent описывает замену ревьювера; без нового ревьювера это снятие
+func reassignedEvent(prID, oldReviewerID, newReviewerID, reason string, at time.Time) domain.PREvent {
+	event := domain.PREvent{
+		PullRequestID: prID,
+		Type:          domain.PREventReassigned,
+		ReviewerID:    oldReviewerID,
+		NewReviewerID: newReviewerID,
+		Reason:        reason,
+		CreatedAt:     at,
+	}
+	if newReviewerID == "" {
+		event.Type = domain.PREventReviewerRemoved
+	}
+	return event
+}
+
+func stateChangedEvent(prID string, from, to domain.PRStatus, at time.Time) domain.PREvent {
+	return domain.PREvent{
+		PullRequestID: prID,
+		Type:          domain.PREventStateChanged,
+		FromStatus:    from,
+		ToStatus:      to,
+		CreatedAt:     at,
+	}
+}
@@ -2,7 +2,8 @@ package usecase
 
 import (
 	"context"
-	"math/rand"
+	"fmt"
+	"slices"
 	"time"
 
 	"pr-reviewer/internal/domain"
@@ -11,24 +12,37 @@ import (
 )
 
 type PRService struct {
-	repo   storage.Repository
-	tx     domain.TransactionManager
-	logger logger.Logger
-	rand   *rand.Rand
+	repo     storage.Repository
+	tx       domain.TransactionManager
+	assigner *ReviewerAssigner
+	logger   logger.Logger
 }
 
-func NewPRService(repo storage.Repository, tx domain.TransactionManager, logger logger.Logger) *PRService {
+func NewPRService(repo storage.Repository, tx domain.TransactionManager, assigner *ReviewerAssigner, logger logger.Logger) *PRService {
 	return &PRService{
-		repo:   repo,
-		tx:     tx,
-		logger: logger,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		repo:     repo,
+		tx:       tx,
+		assigner: assigner,
+		logger:   logger,
 	}
 }
 
 func (s *PRService) CreatePR(ctx context.Context, req domain.CreatePRRequest) (*domain.PullRequestResponse, error) {
+	return s.createPR(ctx, req, false)
+}
+
+// PreviewPR выполняет ту же логику, что и CreatePR, но ничего не записывает
+func (s *PRService) PreviewPR(ctx context.Context, req domain.CreatePRRequest) (*domain.PullRequestResponse, error) {
+	return s.createPR(ctx, req, true)
+}
+
+func (s *PRService) createPR(ctx context.Context, req domain.CreatePRRequest, dryRun bool) (*domain.PullRequestResponse, error) {
 	var result *domain.PullRequestResponse
 
+	if dryRun {
+		ctx = withDryRun(ctx)
+	}
+
 	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
 
 		exists, err := s.repo.PRExists(ctx, req.PullRequestID)
@@ -46,15 +60,48 @@ func (s *PRService) CreatePR(ctx context.Context, req domain.CreatePRRequest) (*
 			return err
 		}
 
-		candidates, err := s.repo.GetActiveTeamMembers(ctx, author.TeamName, req.AuthorID)
+		teamName, err := s.assigner.resolveTeam(ctx, author, req.TeamName)
+		if err != nil {
+			return err
+		}
+
+		settings, err := s.assigner.teamSettings(ctx, teamName)
+		if err != nil {
+			s.logger.Error("Failed to get team settings", "error", err)
+			return err
+		}
+
+		size, err := prSize(req, settings)
 		if err != nil {
-			s.logger.Error("Failed to get team members", "error", err)
 			return err
 		}
 
-		reviewers := s.selectReviewers(candidates, 2)
-		reviewerIDs := make([]string, len(reviewers))
-		for i, r := range reviewers {
+		labels, ok := normalizeLabels(req.Labels)
+		if !ok {
+			return domain.ErrInvalidLabel
+		}
+
+		ctx = withAssignmentTrace(ctx, req.PullRequestID, req.AuthorID, nil)
+
+		// Ревьюверы черновика назначаются, когда его отмечают готовым; параметры назначения
+		// проверяются сразу и сохраняются до этого момента
+		status := domain.PRStatusOpen
+		plan := &reviewerPlan{}
+		var draftInput domain.DraftInput
+		if req.Draft {
+			status = domain.PRStatusDraft
+			draftInput, err = s.draftInput(ctx, req, settings.ForSize(size))
+			if err != nil {
+				return err
+			}
+		} else {
+			plan, err = s.planReviewers(ctx, req, teamName, size)
+			if err != nil {
+				return err
+			}
+		}
+		reviewerIDs := make([]string, len(plan.reviewers))
+		for i, r := range plan.reviewers {
 			reviewerIDs[i] = r.UserID
 		}
 
@@ -63,178 +110,1433 @@ func (s *PRService) CreatePR(ctx context.Context, req domain.CreatePRRequest) (*
 			PullRequestID:   req.PullRequestID,
 			PullRequestName: req.PullRequestName,
 			AuthorID:        req.AuthorID,
-			Status:          domain.PRStatusOpen,
+			TeamName:        teamName,
+			Status:          status,
+			LinesAdded:      req.LinesAdded,
+			LinesDeleted:    req.LinesDeleted,
+			Size:            size,
+			Labels:          labels,
+			DraftInput:      draftInput,
 			CreatedAt:       &now,
 		}
 
-		if err := s.repo.CreatePR(ctx, pr, reviewerIDs); err != nil {
-			s.logger.Error("Failed to create PR", "error", err)
-			return err
-		}
-
 		result = &domain.PullRequestResponse{
 			PullRequestID:     pr.PullRequestID,
 			PullRequestName:   pr.PullRequestName,
 			AuthorID:          pr.AuthorID,
+			TeamName:          pr.TeamName,
 			Status:            pr.Status,
-			AssignedReviewers: reviewerIDs,
+			AssignedReviewers: domain.PendingReviewers(reviewerIDs),
+			FallbackReviewers: plan.fallbackIDs,
+			UncoveredTags:     plan.uncoveredTags,
+			Size:              pr.Size,
+			Labels:            pr.Labels,
 			CreatedAt:         pr.CreatedAt,
 			MergedAt:          pr.MergedAt,
 		}
 
-		return nil
+		if dryRun {
+			return nil
+		}
+
+		if err := s.repo.CreatePR(ctx, pr, reviewerIDs); err != nil {
+			s.logger.Error("Failed to create PR", "error", err)
+			return err
+		}
+
+		events := append([]domain.PREvent{{
+			PullRequestID: pr.PullRequestID,
+			Type:          domain.PREventCreated,
+			ToStatus:      pr.Status,
+			CreatedAt:     now,
+		}}, assignedEvents(pr.PullRequestID, reviewerIDs, now)...)
+		if err := s.addEvents(ctx, events); err != nil {
+			return err
+		}
+
+		return s.saveAssignmentTrace(ctx, reviewerIDs)
 	})
 
 	return result, err
 }
 
-func (s *PRService) MergePR(ctx context.Context, prID string) (*domain.PullRequestResponse, error) {
+// draftInput проверяет параметры назначения черновика так же, как planReviewers, и возвращает их для сохранения
+func (s *PRService) draftInput(ctx context.Context, req domain.CreatePRRequest, settings *domain.TeamSettings) (domain.DraftInput, error) {
+	if _, err := s.requestedReviewers(ctx, req, settings); err != nil {
+		return domain.DraftInput{}, err
+	}
+
+	tags, ok := normalizeSkills(req.RequiredTags)
+	if !ok {
+		return domain.DraftInput{}, domain.ErrInvalidSkill
+	}
+
+	return domain.DraftInput{
+		Repository:         req.Repository,
+		ChangedFiles:       req.ChangedFiles,
+		RequiredTags:       tags,
+		RequestedReviewers: req.RequestedReviewers,
+		ExcludedReviewers:  req.ExcludedReviewers,
+	}, nil
+}
+
+// reviewerPlan описывает выбранных для нового PR ревьюверов
+type reviewerPlan struct {
+	reviewers     []domain.User
+	fallbackIDs   []string
+	uncoveredTags []string
+}
+
+// planReviewers выбирает ревьюверов для нового PR: сначала назначает запрошенных автором,
+// затем старшего ревьювера, если его требует команда, покрывает требуемые теги,
+// потом владельцев кода и команду PR, недостающих добирает из резервных команд.
+// Исключенные автором не выбираются никогда.
+func (s *PRService) planReviewers(ctx context.Context, req domain.CreatePRRequest, teamName string, size domain.PRSize) (*reviewerPlan, error) {
+	candidates, err := s.repo.GetActiveTeamMembers(ctx, teamName, req.AuthorID)
+	if err != nil {
+		s.logger.Error("Failed to get team members", "error", err)
+		return nil, err
+	}
+
+	settings, err := s.assigner.teamSettings(ctx, teamName)
+	if err != nil {
+		s.logger.Error("Failed to get team settings", "error", err)
+		return nil, err
+	}
+	settings = settings.ForSize(size)
+
+	requested, err := s.requestedReviewers(ctx, req, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := s.assigner.codeOwnerCandidates(ctx, req.Repository, teamName, req.AuthorID, req.ChangedFiles)
+	if err != nil {
+		s.logger.Error("Failed to resolve code owners", "error", err)
+		return nil, err
+	}
+
+	tags, ok := normalizeSkills(req.RequiredTags)
+	if !ok {
+		return nil, domain.ErrInvalidSkill
+	}
+
+	exclude := map[string]bool{req.AuthorID: true}
+	for _, userID := range req.ExcludedReviewers {
+		exclude[userID] = true
+	}
+	if trace := traceFromContext(ctx); trace != nil {
+		trace.markExcluded(req.ExcludedReviewers)
+	}
+
+	if err := s.assigner.recordRequested(ctx, requested); err != nil {
+		return nil, err
+	}
+	reviewers := append([]domain.User{}, requested...)
+	for _, r := range requested {
+		exclude[r.UserID] = true
+	}
+
+	// Старшего ревьювера резервируем до остальных шагов, чтобы для него осталось место
+	plan := &reviewerPlan{}
+	if settings.RequireSenior && !hasSenior(reviewers) {
+		if len(reviewers) >= settings.MaxReviewers {
+			return nil, domain.ErrSeniorReviewerRequired
+		}
+
+		senior, fromFallback, err := s.assigner.selectSenior(ctx, req.AuthorID, settings, teamName, append(append([]domain.User{}, owners...), candidates...), exclude)
+		if err != nil {
+			if err != domain.ErrSeniorReviewerRequired {
+				s.logger.Error("Failed to select senior reviewer", "error", err)
+			}
+			return nil, err
+		}
+
+		exclude[senior.UserID] = true
+		reviewers = append(reviewers, *senior)
+		if fromFallback {
+			plan.fallbackIDs = append(plan.fallbackIDs, senior.UserID)
+		}
+	}
+
+	tagged, err := s.assigner.selectForTags(ctx, req.AuthorID, teamName, excludeUsers(candidates, exclude), tags, settings.MaxReviewers-len(reviewers))
+	if err != nil {
+		s.logger.Error("Failed to select reviewers for tags", "error", err)
+		return nil, err
+	}
+	for _, r := range tagged {
+		exclude[r.UserID] = true
+	}
+	reviewers = append(reviewers, tagged...)
+
+	rest, selectErr := s.assigner.selectPreferred(ctx, req.AuthorID, teamName, excludeUsers(owners, exclude), excludeUsers(candidates, exclude), settings.MaxReviewers-len(reviewers))
+	if selectErr != nil && selectErr != domain.ErrReviewersAtCapacity {
+		s.logger.Error("Failed to select reviewers", "error", selectErr)
+		return nil, selectErr
+	}
+	for _, r := range rest {
+		exclude[r.UserID] = true
+	}
+	reviewers = append(reviewers, rest...)
+
+	// Если в команде PR не хватает ревьюверов, добираем из резервных команд
+	if len(reviewers) < settings.MaxReviewers {
+		fallback, err := s.assigner.selectFromFallback(ctx, req.AuthorID, settings, exclude, settings.MaxReviewers-len(reviewers), nil)
+		if err != nil {
+			s.logger.Error("Failed to select fallback reviewers", "error", err)
+			return nil, err
+		}
+
+		for _, r := range fallback {
+			plan.fallbackIDs = append(plan.fallbackIDs, r.UserID)
+		}
+		reviewers = append(reviewers, fallback...)
+	}
+
+	// По иерархии поднимаемся, только если без этого не набрать минимум или нет ни одного ревьювера
+	if shortage := escalationShortage(settings, len(reviewers)); shortage > 0 {
+		escalated, err := s.assigner.selectFromAncestors(ctx, req.AuthorID, teamName, exclude, shortage, nil)
+		if err != nil {
+			s.logger.Error("Failed to select reviewers from parent teams", "error", err)
+			return nil, err
+		}
+		reviewers = append(reviewers, escalated...)
+	}
+
+	if len(reviewers) == 0 && selectErr != nil {
+		return nil, selectErr
+	}
+	if len(reviewers) < settings.MinReviewers {
+		return nil, domain.ErrNotEnoughReviewers
+	}
+
+	plan.reviewers = reviewers
+	plan.uncoveredTags = uncoveredTags(reviewers, tags)
+
+	return plan, nil
+}
+
+// requestedReviewers проверяет запрошенных автором ревьюверов.
+// Лимит открытых ревью и отсутствие не учитываются: автор выбрал их явно.
+func (s *PRService) requestedReviewers(ctx context.Context, req domain.CreatePRRequest, settings *domain.TeamSettings) ([]domain.User, error) {
+	excluded := make(map[string]bool, len(req.ExcludedReviewers))
+	for _, userID := range req.ExcludedReviewers {
+		excluded[userID] = true
+	}
+
+	seen := make(map[string]bool, len(req.RequestedReviewers))
+	users := make([]domain.User, 0, len(req.RequestedReviewers))
+	for _, userID := range req.RequestedReviewers {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if userID == req.AuthorID {
+			return nil, domain.ErrRequestedReviewerIsAuthor
+		}
+		if excluded[userID] {
+			return nil, domain.ErrRequestedReviewerExcluded
+		}
+
+		user, err := s.repo.GetUser(ctx, userID)
+		if err == domain.ErrUserNotFound {
+			return nil, domain.ErrRequestedReviewerNotFound
+		}
+		if err != nil {
+			s.logger.Error("Failed to get requested reviewer", "error", err)
+			return nil, err
+		}
+		if !user.IsActive {
+			return nil, domain.ErrRequestedReviewerInactive
+		}
+
+		users = append(users, *user)
+	}
+
+	if len(users) > settings.MaxReviewers {
+		return nil, domain.ErrTooManyRequestedReviewers
+	}
+
+	return users, nil
+}
+
+// prSize определяет класс размера PR по границам команды; если не передан ни size, ни число строк, размер неизвестен
+func prSize(req domain.CreatePRRequest, settings *domain.TeamSettings) (domain.PRSize, error) {
+	if req.LinesAdded < 0 || req.LinesDeleted < 0 {
+		return "", domain.ErrInvalidPRSize
+	}
+
+	if req.Size != "" {
+		if !req.Size.Valid() {
+			return "", domain.ErrInvalidPRSize
+		}
+		return req.Size, nil
+	}
+
+	if req.LinesAdded == 0 && req.LinesDeleted == 0 {
+		return "", nil
+	}
+
+	return settings.SizeForLines(req.LinesAdded + req.LinesDeleted), nil
+}
+
+// normalizeLabels приводит метки PR к тому же виду, что и навыки: нижний регистр без повторов
+func normalizeLabels(labels []string) ([]string, bool) {
+	return normalizeSkills(labels)
+}
+
+func excludeUsers(users []domain.User, exclude map[string]bool) []domain.User {
+	result := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		if !exclude[u.UserID] {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+// MergePR выполняет merge, если выполнены условия политики команды автора.
+// С req.Force условия не проверяются, а обойденные условия сохраняются в истории PR.
+func (s *PRService) MergePR(ctx context.Context, req domain.MergePRRequest) (*domain.PullRequestResponse, error) {
 	var result *domain.PullRequestResponse
 
 	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
 
-		pr, reviewers, err := s.repo.GetPRWithReviewers(ctx, prID)
+		pr, err := s.repo.GetPR(ctx, req.PullRequestID)
 		if err != nil {
 			return err
 		}
 
 		if pr.Status == domain.PRStatusMerged {
-			result = &domain.PullRequestResponse{
-				PullRequestID:     pr.PullRequestID,
-				PullRequestName:   pr.PullRequestName,
-				AuthorID:          pr.AuthorID,
-				Status:            pr.Status,
-				AssignedReviewers: reviewers,
-				CreatedAt:         pr.CreatedAt,
-				MergedAt:          pr.MergedAt,
-			}
-			return nil
+			result, err = s.prResponse(ctx, pr)
+			return err
 		}
 
-		if err := s.repo.MergePR(ctx, prID); err != nil {
-			return err
+		if !pr.Status.CanTransitionTo(domain.PRStatusMerged) {
+			return domain.NewInvalidTransitionError(pr.Status, domain.PRStatusMerged)
 		}
 
-		pr, reviewers, err = s.repo.GetPRWithReviewers(ctx, prID)
+		conditions, err := s.unmetMergeConditions(ctx, pr)
 		if err != nil {
 			return err
 		}
+		if len(conditions) > 0 && !req.Force {
+			return domain.NewMergeBlockedError(conditions)
+		}
 
-		result = &domain.PullRequestResponse{
-			PullRequestID:     pr.PullRequestID,
-			PullRequestName:   pr.PullRequestName,
-			AuthorID:          pr.AuthorID,
-			Status:            pr.Status,
-			AssignedReviewers: reviewers,
-			CreatedAt:         pr.CreatedAt,
-			MergedAt:          pr.MergedAt,
+		if err := s.repo.MergePR(ctx, req.PullRequestID); err != nil {
+			return err
 		}
 
-		return nil
+		event := &domain.PREvent{
+			PullRequestID: req.PullRequestID,
+			Type:          domain.PREventMerged,
+			Forced:        req.Force,
+			Conditions:    conditions,
+			CreatedAt:     time.Now(),
+		}
+		if err := s.repo.AddPREvent(ctx, event); err != nil {
+			s.logger.Error("Failed to save PR event", "error", err)
+			return err
+		}
+
+		if req.Force && len(conditions) > 0 {
+			s.logger.Warn("PR merged with unmet merge conditions", "pr_id", req.PullRequestID, "conditions", conditions)
+		}
+
+		pr, err = s.repo.GetPR(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		result, err = s.prResponse(ctx, pr)
+		return err
 	})
 
 	return result, err
 }
 
-func (s *PRService) ReassignReviewer(ctx context.Context, req domain.ReassignRequest) (*domain.ReassignResponse, error) {
-	var result *domain.ReassignResponse
+// unmetMergeConditions проверяет PR по политике merge команды PR
+// и возвращает описания невыполненных условий
+func (s *PRService) unmetMergeConditions(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	teamName, err := s.assigner.prTeam(ctx, pr)
+	if err != nil {
+		s.logger.Error("Failed to get PR team", "error", err)
+		return nil, err
+	}
 
-	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
-		pr, reviewers, err := s.repo.GetPRWithReviewers(ctx, req.PullRequestID)
-		if err != nil {
-			s.logger.Error("Failed to get PR with reviewers", "error", err)
-			return err
+	settings, err := s.assigner.teamSettings(ctx, teamName)
+	if err != nil {
+		s.logger.Error("Failed to get team settings", "error", err)
+		return nil, err
+	}
+	policy := settings.MergePolicy
+
+	states, err := s.repo.GetPRReviewerStates(ctx, pr.PullRequestID)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer states", "error", err)
+		return nil, err
+	}
+
+	conditions := make([]string, 0)
+	approvers := make([]string, 0, len(states))
+	for _, state := range states {
+		switch state.State {
+		case domain.ReviewStateApproved:
+			approvers = append(approvers, state.ReviewerID)
+		case domain.ReviewStateChangesRequested:
+			if policy.NoChangesRequested {
+				conditions = append(conditions, fmt.Sprintf("changes requested by %s", state.ReviewerID))
+			}
 		}
+	}
 
-		if pr.Status == domain.PRStatusMerged {
-			return domain.ErrPRMerged
+	if len(approvers) < policy.MinApprovals {
+		conditions = append(conditions, fmt.Sprintf("%d of %d required approvals", len(approvers), policy.MinApprovals))
+	}
+
+	if policy.RequiredApprovalTeam != "" {
+		approved := false
+		for _, approverID := range approvers {
+			teams, err := s.repo.GetUserTeams(ctx, approverID)
+			if err != nil {
+				s.logger.Error("Failed to get approver teams", "error", err)
+				return nil, err
+			}
+			if slices.Contains(teams, policy.RequiredApprovalTeam) {
+				approved = true
+				break
+			}
 		}
+		if !approved {
+			conditions = append(conditions, fmt.Sprintf("approval from team %s required", policy.RequiredApprovalTeam))
+		}
+	}
+
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	return conditions, nil
+}
 
-		isAssigned, err := s.repo.IsReviewerAssigned(ctx, req.PullRequestID, req.OldUserID)
+// MarkReady переводит черновик в OPEN и назначает ревьюверов так же, как при создании PR,
+// с параметрами назначения из черновика, если они не переданы заново
+func (s *PRService) MarkReady(ctx context.Context, req domain.MarkReadyRequest) (*domain.PullRequestResponse, error) {
+	var result *domain.PullRequestResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.repo.GetPR(ctx, req.PullRequestID)
 		if err != nil {
-			s.logger.Error("Failed to check reviewer assignment", "error", err)
 			return err
 		}
-		if !isAssigned {
-			return domain.ErrReviewerNotAssigned
+
+		if err := checkTransition(pr, domain.PRStatusDraft, domain.PRStatusOpen); err != nil {
+			return err
 		}
 
-		oldReviewer, err := s.repo.GetUser(ctx, req.OldUserID)
+		teamName, err := s.assigner.prTeam(ctx, pr)
 		if err != nil {
-			s.logger.Error("Failed to get old reviewer", "error", err)
+			s.logger.Error("Failed to get PR team", "error", err)
 			return err
 		}
 
-		candidates, err := s.repo.GetActiveTeamMembers(ctx, oldReviewer.TeamName, req.OldUserID)
+		ctx = withAssignmentTrace(ctx, pr.PullRequestID, pr.AuthorID, nil)
+
+		plan, err := s.planReviewers(ctx, readyRequest(pr, req), teamName, pr.Size)
 		if err != nil {
-			s.logger.Error("Failed to get team candidates", "error", err)
 			return err
 		}
 
-		available := s.filterAvailableReviewers(candidates, pr, reviewers)
-
-		if len(available) == 0 {
-			return domain.ErrNoActiveCandidate
+		if err := s.repo.SetPRStatus(ctx, pr.PullRequestID, domain.PRStatusOpen); err != nil {
+			s.logger.Error("Failed to update PR status", "error", err)
+			return err
 		}
 
-		newReviewer := available[s.rand.Intn(len(available))]
+		now := time.Now()
+		reviewerIDs := make([]string, len(plan.reviewers))
+		for i, r := range plan.reviewers {
+			reviewerIDs[i] = r.UserID
+			if err := s.repo.AddReviewer(ctx, pr.PullRequestID, r.UserID, now); err != nil {
+				s.logger.Error("Failed to add reviewer", "error", err)
+				return err
+			}
+		}
 
-		if err := s.repo.RemoveReviewer(ctx, req.PullRequestID, req.OldUserID); err != nil {
-			s.logger.Error("Failed to remove old reviewer", "error", err)
+		if err := s.saveAssignmentTrace(ctx, reviewerIDs); err != nil {
 			return err
 		}
 
-		if err := s.repo.AddReviewer(ctx, req.PullRequestID, newReviewer.UserID); err != nil {
-			s.logger.Error("Failed to add new reviewer", "error", err)
+		events := append([]domain.PREvent{stateChangedEvent(pr.PullRequestID, pr.Status, domain.PRStatusOpen, now)}, assignedEvents(pr.PullRequestID, reviewerIDs, now)...)
+		if err := s.addEvents(ctx, events); err != nil {
 			return err
 		}
 
-		updatedPR, revs, err := s.repo.GetPRWithReviewers(ctx, req.PullRequestID)
+		pr.Status = domain.PRStatusOpen
+		result, err = s.prResponse(ctx, pr)
 		if err != nil {
-			s.logger.Error("Failed to get updated PR", "error", err)
 			return err
 		}
-
-		result = &domain.ReassignResponse{
-			PR: domain.PullRequestResponse{
-				PullRequestID:     updatedPR.PullRequestID,
-				PullRequestName:   updatedPR.PullRequestName,
-				AuthorID:          updatedPR.AuthorID,
-				Status:            updatedPR.Status,
-				AssignedReviewers: revs,
-				CreatedAt:         updatedPR.CreatedAt,
-				MergedAt:          updatedPR.MergedAt,
-			},
-			ReplacedBy: newReviewer.UserID,
-		}
+		result.FallbackReviewers = plan.fallbackIDs
+		result.UncoveredTags = plan.uncoveredTags
 
 		return nil
 	})
 
-	if err != nil {
-		s.logger.Error("Reassign reviewer failed", "error", err)
-	}
-
 	return result, err
 }
 
-func (s *PRService) selectReviewers(candidates []domain.User, n int) []domain.User {
-	if len(candidates) == 0 {
-		return []domain.User{}
+// readyRequest собирает запрос на назначение ревьюверов черновика: поля из req заменяют
+// сохраненные при создании, незаданные берутся из черновика
+func readyRequest(pr *domain.PullRequest, req domain.MarkReadyRequest) domain.CreatePRRequest {
+	input := pr.DraftInput
+	if req.Repository != "" {
+		input.Repository = req.Repository
+	}
+	if req.ChangedFiles != nil {
+		input.ChangedFiles = req.ChangedFiles
+	}
+	if req.RequiredTags != nil {
+		input.RequiredTags = req.RequiredTags
+	}
+	if req.RequestedReviewers != nil {
+		input.RequestedReviewers = req.RequestedReviewers
+	}
+	if req.ExcludedReviewers != nil {
+		input.ExcludedReviewers = req.ExcludedReviewers
 	}
 
-	if len(candidates) <= n {
-		return candidates
+	return domain.CreatePRRequest{
+		PullRequestID:      pr.PullRequestID,
+		PullRequestName:    pr.PullRequestName,
+		AuthorID:           pr.AuthorID,
+		Repository:         input.Repository,
+		ChangedFiles:       input.ChangedFiles,
+		RequiredTags:       input.RequiredTags,
+		RequestedReviewers: input.RequestedReviewers,
+		ExcludedReviewers:  input.ExcludedReviewers,
 	}
+}
+
+// ClosePR закрывает PR без merge. Назначения сохраняются, но перестают учитываться в нагрузке ревьюверов.
+func (s *PRService) ClosePR(ctx context.Context, req domain.ClosePRRequest) (*domain.PullRequestResponse, error) {
+	var result *domain.PullRequestResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.repo.GetPR(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTransition(pr, pr.Status, domain.PRStatusClosed); err != nil {
+			return err
+		}
+
+		if err := s.repo.SetPRStatus(ctx, pr.PullRequestID, domain.PRStatusClosed); err != nil {
+			s.logger.Error("Failed to update PR status", "error", err)
+			return err
+		}
+
+		if err := s.addEvents(ctx, []domain.PREvent{stateChangedEvent(pr.PullRequestID, pr.Status, domain.PRStatusClosed, time.Now())}); err != nil {
+			return err
+		}
 
-	perm := s.rand.Perm(len(candidates))
+		pr.Status = domain.PRStatusClosed
+		result, err = s.prResponse(ctx, pr)
+		return err
+	})
+
+	return result, err
+}
+
+// ReopenPR возвращает закрытый PR в OPEN с прежними ревьюверами. Ревьюверы, успевшие стать неактивными,
+// заменяются так же, как в ReassignReviewer, а если заменить некем — снимаются.
+// SLA ревью отсчитывается заново с момента переоткрытия.
+func (s *PRService) ReopenPR(ctx context.Context, req domain.ReopenPRRequest) (*domain.PullRequestResponse, error) {
+	var result *domain.PullRequestResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, reviewers, err := s.repo.GetPRWithReviewers(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTransition(pr, domain.PRStatusClosed, domain.PRStatusOpen); err != nil {
+			return err
+		}
+
+		inactive := make([]string, 0)
+		for _, reviewerID := range reviewers {
+			reviewer, err := s.repo.GetUser(ctx, reviewerID)
+			if err != nil {
+				s.logger.Error("Failed to get reviewer", "error", err)
+				return err
+			}
+			if !reviewer.IsActive {
+				inactive = append(inactive, reviewerID)
+			}
+		}
+
+		now := time.Now()
+		if err := s.repo.SetPRStatus(ctx, pr.PullRequestID, domain.PRStatusOpen); err != nil {
+			s.logger.Error("Failed to update PR status", "error", err)
+			return err
+		}
+
+		if err := s.repo.ResetReviewerAssignments(ctx, pr.PullRequestID, now); err != nil {
+			s.logger.Error("Failed to reset reviewer assignments", "error", err)
+			return err
+		}
+
+		if err := s.addEvents(ctx, []domain.PREvent{stateChangedEvent(pr.PullRequestID, pr.Status, domain.PRStatusOpen, now)}); err != nil {
+			return err
+		}
+		pr.Status = domain.PRStatusOpen
+
+		// Замены подбираются уже для открытого PR, как при ручном переназначении
+		for _, reviewerID := range inactive {
+			if err := s.releaseReviewer(ctx, pr, reviewerID, domain.PREventReasonInactive, now); err != nil {
+				return err
+			}
+		}
 
-	selected := make([]domain.User, n)
-	for i := 0; i < n; i++ {
-		selected[i] = candidates[perm[i]]
+		if err := s.checkReviewerShortage(ctx, pr); err != nil {
+			return err
+		}
+
+		result, err = s.prResponse(ctx, pr)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdatePR меняет название, метки и автора PR. Если новый автор не состоит в команде PR,
+// PR переходит в его основную команду, и ревьюверы открытого PR подбираются заново по ее правилам
+// (см. reselectForTeam). Иначе команда не меняется, а новый автор, если он назначен ревьювером
+// своего PR, снимается с ревью; у открытого PR замена подбирается так же, как в ReassignReviewer.
+func (s *PRService) UpdatePR(ctx context.Context, req domain.UpdatePRRequest) (*domain.PullRequestResponse, error) {
+	labels, ok := normalizeLabels(req.Labels)
+	if !ok {
+		return nil, domain.ErrInvalidLabel
+	}
+
+	var result *domain.PullRequestResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.repo.GetPR(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		updated := *pr
+		fields := make([]string, 0, 4)
+		if req.PullRequestName != "" && req.PullRequestName != pr.PullRequestName {
+			updated.PullRequestName = req.PullRequestName
+			fields = append(fields, "pull_request_name")
+		}
+		if labels != nil && !slices.Equal(labels, pr.Labels) {
+			updated.Labels = labels
+			fields = append(fields, "labels")
+		}
+
+		authorChanged := req.AuthorID != "" && req.AuthorID != pr.AuthorID
+		if authorChanged {
+			if pr.Status == domain.PRStatusMerged {
+				return domain.ErrAuthorChangeOnMergedPR
+			}
+			author, err := s.repo.GetUser(ctx, req.AuthorID)
+			if err != nil {
+				s.logger.Error("Failed to get new author", "error", err)
+				return err
+			}
+			updated.AuthorID = req.AuthorID
+			fields = append(fields, "author_id")
+
+			teamName, err := s.assigner.prTeam(ctx, pr)
+			if err != nil {
+				s.logger.Error("Failed to get PR team", "error", err)
+				return err
+			}
+			teams, err := s.repo.GetUserTeams(ctx, author.UserID)
+			if err != nil {
+				s.logger.Error("Failed to get author teams", "error", err)
+				return err
+			}
+			if !slices.Contains(teams, teamName) {
+				updated.TeamName = author.TeamName
+				fields = append(fields, "team_name")
+			}
+		}
+
+		if len(fields) > 0 {
+			if err := s.repo.UpdatePR(ctx, &updated); err != nil {
+				s.logger.Error("Failed to update PR", "error", err)
+				return err
+			}
+
+			event := domain.PREvent{
+				PullRequestID: pr.PullRequestID,
+				Type:          domain.PREventUpdated,
+				Fields:        fields,
+				CreatedAt:     time.Now(),
+			}
+			if authorChanged {
+				event.FromAuthorID = pr.AuthorID
+				event.ToAuthorID = updated.AuthorID
+			}
+			if err := s.addEvents(ctx, []domain.PREvent{event}); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case authorChanged && updated.TeamName != pr.TeamName && updated.TeamName != "" && updated.Status == domain.PRStatusOpen:
+			if err := s.reselectForTeam(ctx, &updated); err != nil {
+				return err
+			}
+		case authorChanged:
+			if err := s.dropAuthorReview(ctx, &updated); err != nil {
+				return err
+			}
+		}
+
+		result, err = s.prResponse(ctx, &updated)
+		return err
+	})
+
+	return result, err
+}
+
+// reselectForTeam приводит ревьюверов открытого PR к правилам его новой команды; вызывается внутри
+// транзакции после смены команды. Остаются активные участники новой команды, кроме автора, в пределах
+// ее максимума, недостающие подбираются так же, как при создании PR. Если правила новой команды
+// выполнить нельзя, остаются только подходящие ревьюверы, а нехватка пишется в лог.
+func (s *PRService) reselectForTeam(ctx context.Context, pr *domain.PullRequest) error {
+	reviewers, err := s.repo.GetPRReviewers(ctx, pr.PullRequestID)
+	if err != nil {
+		s.logger.Error("Failed to get PR reviewers", "error", err)
+		return err
+	}
+
+	settings, err := s.assigner.teamSettings(ctx, pr.TeamName)
+	if err != nil {
+		s.logger.Error("Failed to get team settings", "error", err)
+		return err
+	}
+	settings = settings.ForSize(pr.Size)
+
+	kept := make([]string, 0, len(reviewers))
+	for _, reviewerID := range reviewers {
+		if reviewerID == pr.AuthorID || len(kept) >= settings.MaxReviewers {
+			continue
+		}
+
+		reviewer, err := s.repo.GetUser(ctx, reviewerID)
+		if err != nil {
+			s.logger.Error("Failed to get reviewer", "error", err)
+			return err
+		}
+		teams, err := s.repo.GetUserTeams(ctx, reviewerID)
+		if err != nil {
+			s.logger.Error("Failed to get reviewer teams", "error", err)
+			return err
+		}
+		if reviewer.IsActive && slices.Contains(teams, pr.TeamName) {
+			kept = append(kept, reviewerID)
+		}
+	}
+
+	// Оставшиеся ревьюверы передаются как запрошенные, чтобы сохранить их и их решения
+	ctx = withAssignmentTrace(ctx, pr.PullRequestID, pr.AuthorID, nil)
+	target := kept
+	plan, planErr := s.planReviewers(ctx, domain.CreatePRRequest{
+		PullRequestID:      pr.PullRequestID,
+		PullRequestName:    pr.PullRequestName,
+		AuthorID:           pr.AuthorID,
+		RequestedReviewers: kept,
+	}, pr.TeamName, pr.Size)
+	switch planErr {
+	case nil:
+		target = make([]string, len(plan.reviewers))
+		for i, r := range plan.reviewers {
+			target[i] = r.UserID
+		}
+	case domain.ErrNotEnoughReviewers, domain.ErrNoActiveCandidate, domain.ErrReviewersAtCapacity, domain.ErrSeniorReviewerRequired:
+	default:
+		return planErr
+	}
+
+	now := time.Now()
+	removed := make([]string, 0)
+	for _, reviewerID := range reviewers {
+		if slices.Contains(target, reviewerID) {
+			continue
+		}
+		if err := s.repo.RemoveReviewer(ctx, pr.PullRequestID, reviewerID); err != nil {
+			s.logger.Error("Failed to remove reviewer", "error", err)
+			return err
+		}
+		removed = append(removed, reviewerID)
+	}
+
+	added := make([]string, 0)
+	for _, reviewerID := range target {
+		if slices.Contains(reviewers, reviewerID) {
+			continue
+		}
+		if err := s.repo.AddReviewer(ctx, pr.PullRequestID, reviewerID, now); err != nil {
+			s.logger.Error("Failed to add reviewer", "error", err)
+			return err
+		}
+		added = append(added, reviewerID)
+	}
+
+	if err := s.saveAssignmentTrace(ctx, added); err != nil {
+		return err
+	}
+
+	// Снятые ревьюверы по порядку считаются замененными новыми, лишние снятия и назначения пишутся отдельно
+	events := make([]domain.PREvent, 0, len(removed)+len(added))
+	for i, reviewerID := range removed {
+		reason := domain.PREventReasonTeamChange
+		if reviewerID == pr.AuthorID {
+			reason = domain.PREventReasonAuthorChange
+		}
+		newReviewerID := ""
+		if i < len(added) {
+			newReviewerID = added[i]
+		}
+		events = append(events, reassignedEvent(pr.PullRequestID, reviewerID, newReviewerID, reason, now))
+	}
+	if len(added) > len(removed) {
+		events = append(events, assignedEvents(pr.PullRequestID, added[len(removed):], now)...)
+	}
+	if err := s.addEvents(ctx, events); err != nil {
+		return err
+	}
+
+	if planErr != nil {
+		return s.checkReviewerShortage(ctx, pr)
+	}
+
+	return nil
+}
+
+// dropAuthorReview снимает автора с ревью его же PR; вызывается внутри транзакции после смены автора.
+// Если заменить некем, автор снимается без замены, чтобы смена автора не блокировалась.
+func (s *PRService) dropAuthorReview(ctx context.Context, pr *domain.PullRequest) error {
+	assigned, err := s.repo.IsReviewerAssigned(ctx, pr.PullRequestID, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("Failed to check reviewer assignment", "error", err)
+		return err
+	}
+	if !assigned {
+		return nil
+	}
+
+	return s.releaseReviewer(ctx, pr, pr.AuthorID, domain.PREventReasonAuthorChange, time.Now())
+}
+
+// releaseReviewer снимает ревьювера с PR; у открытого PR замена подбирается так же, как в ReassignReviewer.
+// Если заменить некем, ревьювер снимается без замены, а в историю пишется снятие, а не замена.
+func (s *PRService) releaseReviewer(ctx context.Context, pr *domain.PullRequest, reviewerID, reason string, at time.Time) error {
+	if pr.Status == domain.PRStatusOpen {
+		_, err := s.swapReviewer(ctx, domain.ReassignRequest{PullRequestID: pr.PullRequestID, OldUserID: reviewerID}, reason, nil, at)
+		switch err {
+		case nil:
+			return nil
+		case domain.ErrNoActiveCandidate, domain.ErrReviewersAtCapacity, domain.ErrSeniorReviewerRequired:
+		default:
+			return err
+		}
+	}
+
+	if err := s.repo.RemoveReviewer(ctx, pr.PullRequestID, reviewerID); err != nil {
+		s.logger.Error("Failed to remove reviewer", "error", err)
+		return err
+	}
+
+	return s.addEvents(ctx, []domain.PREvent{reassignedEvent(pr.PullRequestID, reviewerID, "", reason, at)})
+}
+
+// checkReviewerShortage сообщает в лог, если у PR меньше ревьюверов, чем требует команда,
+// или нет требуемого старшего ревьювера, как при переназначении ревью деактивированных
+func (s *PRService) checkReviewerShortage(ctx context.Context, pr *domain.PullRequest) error {
+	teamName, err := s.assigner.prTeam(ctx, pr)
+	if err != nil {
+		s.logger.Error("Failed to get PR team", "error", err)
+		return err
+	}
+
+	settings, err := s.assigner.teamSettings(ctx, teamName)
+	if err != nil {
+		s.logger.Error("Failed to get team settings", "error", err)
+		return err
+	}
+	settings = settings.ForSize(pr.Size)
+
+	reviewers, err := s.repo.GetPRReviewers(ctx, pr.PullRequestID)
+	if err != nil {
+		s.logger.Error("Failed to get PR reviewers", "error", err)
+		return err
+	}
+
+	if len(reviewers) < settings.MinReviewers {
+		s.logger.Warn("PR has fewer reviewers than team minimum",
+			"pr_id", pr.PullRequestID,
+			"reviewers", len(reviewers),
+			"min_reviewers", settings.MinReviewers)
+	}
+
+	if settings.RequireSenior {
+		hasSenior, err := s.assigner.hasSeniorReviewer(ctx, reviewers)
+		if err != nil {
+			s.logger.Error("Failed to check senior reviewers", "error", err)
+			return err
+		}
+		if !hasSenior {
+			s.logger.Warn("PR has no senior reviewer required by team",
+				"pr_id", pr.PullRequestID,
+				"team_name", teamName)
+		}
+	}
+
+	return nil
+}
+
+// checkTransition проверяет, что PR находится в статусе from и из него разрешен переход в to
+func checkTransition(pr *domain.PullRequest, from, to domain.PRStatus) error {
+	if pr.Status != from || !from.CanTransitionTo(to) {
+		return domain.NewInvalidTransitionError(pr.Status, to)
+	}
+	return nil
+}
+
+// SubmitReview сохраняет решение назначенного ревьювера; повторная отправка заменяет прежнее решение
+func (s *PRService) SubmitReview(ctx context.Context, req domain.SubmitReviewRequest) (*domain.PullRequestResponse, error) {
+	if !req.State.Submittable() {
+		return nil, domain.ErrInvalidReviewState
+	}
+
+	var result *domain.PullRequestResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.repo.GetPR(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == domain.PRStatusMerged {
+			return domain.ErrReviewOnMergedPR
+		}
+		if pr.Status != domain.PRStatusOpen {
+			return domain.ErrPRNotOpen
+		}
+
+		if err := s.repo.SubmitReview(ctx, req.PullRequestID, req.ReviewerID, req.State, time.Now()); err != nil {
+			if err != domain.ErrReviewerNotAssigned {
+				s.logger.Error("Failed to submit review", "error", err)
+			}
+			return err
+		}
+
+		result, err = s.prResponse(ctx, pr)
+		return err
+	})
+
+	return result, err
+}
+
+// prResponse собирает ответ по PR вместе с решениями ревьюверов
+func (s *PRService) prResponse(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequestResponse, error) {
+	reviewers, err := s.reviewerStatuses(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PullRequestResponse{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		TeamName:          pr.TeamName,
+		Status:            pr.Status,
+		AssignedReviewers: reviewers,
+		Size:              pr.Size,
+		Labels:            pr.Labels,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+	}, nil
+}
+
+func (s *PRService) reviewerStatuses(ctx context.Context, prID string) ([]domain.ReviewerStatus, error) {
+	states, err := s.repo.GetPRReviewerStates(ctx, prID)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer states", "error", err)
+		return nil, err
+	}
+
+	result := make([]domain.ReviewerStatus, len(states))
+	for i, state := range states {
+		result[i] = domain.ReviewerStatus{
+			UserID:     state.ReviewerID,
+			State:      state.State,
+			ReviewedAt: state.ReviewedAt,
+		}
+	}
+
+	return result, nil
+}
+
+func (s *PRService) ReassignReviewer(ctx context.Context, req domain.ReassignRequest) (*domain.ReassignResponse, error) {
+	return s.reassignReviewer(ctx, req, false)
+}
+
+// PreviewReassign возвращает ревьювера, который был бы назначен, ничего не записывая
+func (s *PRService) PreviewReassign(ctx context.Context, req domain.ReassignRequest) (*domain.ReassignResponse, error) {
+	return s.reassignReviewer(ctx, req, true)
+}
+
+func (s *PRService) reassignReviewer(ctx context.Context, req domain.ReassignRequest, dryRun bool) (*domain.ReassignResponse, error) {
+	var result *domain.ReassignResponse
+
+	if dryRun {
+		ctx = withDryRun(ctx)
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.swapReviewer(ctx, req, domain.PREventReasonManual, nil, time.Now())
+		return err
+	})
+
+	if err != nil {
+		s.logger.Error("Reassign reviewer failed", "error", err)
+	}
+
+	return result, err
+}
+
+// swapReviewer снимает ревьювера и подбирает ему замену; вызывается внутри транзакции.
+// Пользователи из exclude, как и текущие ревьюверы, заменой не назначаются; reason попадает в историю PR.
+// Замена считается назначенной в момент at.
+func (s *PRService) swapReviewer(ctx context.Context, req domain.ReassignRequest, reason string, exclude []string, at time.Time) (*domain.ReassignResponse, error) {
+	pr, reviewers, err := s.repo.GetPRWithReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		s.logger.Error("Failed to get PR with reviewers", "error", err)
+		return nil, err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.ErrPRMerged
+	}
+	if pr.Status != domain.PRStatusOpen {
+		return nil, domain.ErrPRNotOpen
+	}
+
+	isAssigned, err := s.repo.IsReviewerAssigned(ctx, req.PullRequestID, req.OldUserID)
+	if err != nil {
+		s.logger.Error("Failed to check reviewer assignment", "error", err)
+		return nil, err
+	}
+	if !isAssigned {
+		return nil, domain.ErrReviewerNotAssigned
+	}
+
+	teamName, err := s.assigner.prTeam(ctx, pr)
+	if err != nil {
+		s.logger.Error("Failed to get PR team", "error", err)
+		return nil, err
+	}
+
+	settings, err := s.assigner.teamSettings(ctx, teamName)
+	if err != nil {
+		s.logger.Error("Failed to get team settings", "error", err)
+		return nil, err
+	}
+	settings = settings.ForSize(pr.Size)
+
+	ctx = withAssignmentTrace(ctx, pr.PullRequestID, pr.AuthorID, reviewers)
+	taken := append(append([]string{}, reviewers...), exclude...)
+
+	needSenior, err := s.needsSeniorReplacement(ctx, settings, reviewers, req.OldUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Переназначение всегда меняет ревьювера на другого и не меняет их число,
+	// даже если у PR уже больше ревьюверов, чем максимум команды
+	var newReviewerID string
+	var fromFallback bool
+	if needSenior {
+		newReviewerID, fromFallback, err = s.findSeniorReplacement(ctx, settings, teamName, pr, taken, req.OldUserID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newReviewerID, err = s.findReplacement(ctx, teamName, pr, taken, req.OldUserID)
+		if err == domain.ErrNoActiveCandidate || err == domain.ErrReviewersAtCapacity {
+			newReviewerID, fromFallback, err = s.findFallbackReplacement(ctx, settings, pr, taken, err)
+		}
+		if err == domain.ErrNoActiveCandidate || err == domain.ErrReviewersAtCapacity {
+			newReviewerID, err = s.findAncestorReplacement(ctx, teamName, pr, taken, req.OldUserID, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isDryRun(ctx) {
+		current, err := s.reviewerStatuses(ctx, req.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		revs := make([]domain.ReviewerStatus, 0, len(current))
+		for _, r := range current {
+			if r.UserID != req.OldUserID {
+				revs = append(revs, r)
+			}
+		}
+		if newReviewerID != "" {
+			revs = append(revs, domain.PendingReviewers([]string{newReviewerID})...)
+		}
+
+		return reassignResponse(pr, revs, newReviewerID, fromFallback), nil
+	}
+
+	if err := s.repo.RemoveReviewer(ctx, req.PullRequestID, req.OldUserID); err != nil {
+		s.logger.Error("Failed to remove old reviewer", "error", err)
+		return nil, err
+	}
+
+	if newReviewerID != "" {
+		if err := s.repo.AddReviewer(ctx, req.PullRequestID, newReviewerID, at); err != nil {
+			s.logger.Error("Failed to add new reviewer", "error", err)
+			return nil, err
+		}
+
+		if err := s.saveAssignmentTrace(ctx, []string{newReviewerID}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.addEvents(ctx, []domain.PREvent{reassignedEvent(req.PullRequestID, req.OldUserID, newReviewerID, reason, at)}); err != nil {
+		return nil, err
+	}
+
+	updatedPR, err := s.repo.GetPR(ctx, req.PullRequestID)
+	if err != nil {
+		s.logger.Error("Failed to get updated PR", "error", err)
+		return nil, err
+	}
+
+	revs, err := s.reviewerStatuses(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reassignResponse(updatedPR, revs, newReviewerID, fromFallback), nil
+}
+
+// EscalateReview обрабатывает нарушение SLA ревью. При действии reassign ревьювер заменяется,
+// как в ReassignReviewer; если замены нет или выбрано уведомление, назначение помечается
+// эскалированным, чтобы не уведомлять повторно. Эскалация сохраняется в истории PR.
+func (s *PRService) EscalateReview(ctx context.Context, review domain.PendingReview, action domain.SLAAction, at time.Time) (*domain.PREvent, error) {
+	var event *domain.PREvent
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		event = &domain.PREvent{
+			PullRequestID: review.PullRequestID,
+			Type:          domain.PREventSLAEscalated,
+			ReviewerID:    review.ReviewerID,
+			Reason:        string(domain.SLAActionNotify),
+			CreatedAt:     at,
+		}
+
+		if action == domain.SLAActionReassign {
+			escalated, err := s.escalatedReviewers(ctx, review.PullRequestID)
+			if err != nil {
+				return err
+			}
+
+			// Замену ищем среди тех, кто еще не пропускал SLA на этом PR
+			resp, err := s.swapReviewer(ctx, domain.ReassignRequest{PullRequestID: review.PullRequestID, OldUserID: review.ReviewerID}, domain.PREventReasonSLA, escalated, at)
+			switch err {
+			case nil:
+				event.Reason = string(domain.SLAActionReassign)
+				event.NewReviewerID = resp.ReplacedBy
+			case domain.ErrNoActiveCandidate, domain.ErrReviewersAtCapacity, domain.ErrSeniorReviewerRequired:
+				s.logger.Warn("No replacement for overdue reviewer, sending notification", "pr_id", review.PullRequestID, "reviewer_id", review.ReviewerID)
+			default:
+				return err
+			}
+		}
+
+		if event.Reason == string(domain.SLAActionNotify) {
+			if err := s.repo.MarkReviewEscalated(ctx, review.PullRequestID, review.ReviewerID, at); err != nil {
+				s.logger.Error("Failed to mark review escalated", "error", err)
+				return err
+			}
+		}
+
+		if err := s.repo.AddPREvent(ctx, event); err != nil {
+			s.logger.Error("Failed to save PR event", "error", err)
+			return err
+		}
+
+		return nil
+	})
+
+	return event, err
+}
+
+func (s *PRService) escalatedReviewers(ctx context.Context, prID string) ([]string, error) {
+	events, err := s.repo.GetPREvents(ctx, prID)
+	if err != nil {
+		s.logger.Error("Failed to get PR events", "error", err)
+		return nil, err
+	}
+
+	escalated := make([]string, 0)
+	for _, event := range events {
+		if event.Type == domain.PREventSLAEscalated {
+			escalated = append(escalated, event.ReviewerID)
+		}
+	}
+
+	return escalated, nil
+}
+
+func reassignResponse(pr *domain.PullRequest, reviewers []domain.ReviewerStatus, newReviewerID string, fromFallback bool) *domain.ReassignResponse {
+	result := &domain.ReassignResponse{
+		PR: domain.PullRequestResponse{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			Status:            pr.Status,
+			AssignedReviewers: reviewers,
+			Size:              pr.Size,
+			CreatedAt:         pr.CreatedAt,
+			MergedAt:          pr.MergedAt,
+		},
+		ReplacedBy: newReviewerID,
+	}
+	if fromFallback {
+		result.PR.FallbackReviewers = []string{newReviewerID}
+	}
+
+	return result
+}
+
+// GetHistory возвращает события PR в порядке записи
+func (s *PRService) GetHistory(ctx context.Context, prID string) (*domain.PRHistoryResponse, error) {
+	if _, err := s.repo.GetPR(ctx, prID); err != nil {
+		s.logger.Error("Failed to get PR", "error", err)
+		return nil, err
+	}
+
+	events, err := s.repo.GetPREvents(ctx, prID)
+	if err != nil {
+		s.logger.Error("Failed to get PR events", "error", err)
+		return nil, err
+	}
+
+	return &domain.PRHistoryResponse{
+		PullRequestID: prID,
+		Events:        events,
+	}, nil
+}
+
+func (s *PRService) addEvents(ctx context.Context, events []domain.PREvent) error {
+	if err := addPREvents(ctx, s.repo, events); err != nil {
+		s.logger.Error("Failed to save PR events", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *PRService) GetAssignmentTrace(ctx context.Context, prID string) (*domain.AssignmentTraceResponse, error) {
+	if _, err := s.repo.GetPR(ctx, prID); err != nil {
+		s.logger.Error("Failed to get PR", "error", err)
+		return nil, err
+	}
+
+	records, err := s.repo.GetAssignmentRecords(ctx, prID)
+	if err != nil {
+		s.logger.Error("Failed to get assignment records", "error", err)
+		return nil, err
+	}
+
+	return &domain.AssignmentTraceResponse{
+		PullRequestID: prID,
+		Records:       records,
+	}, nil
+}
+
+// saveAssignmentTrace сохраняет записи трассы для назначенных ревьюверов
+func (s *PRService) saveAssignmentTrace(ctx context.Context, reviewerIDs []string) error {
+	records := traceRecords(ctx, reviewerIDs)
+	if len(records) == 0 {
+		return nil
+	}
+
+	if err := s.repo.SaveAssignmentRecords(ctx, records); err != nil {
+		s.logger.Error("Failed to save assignment records", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *PRService) findReplacement(ctx context.Context, prTeam string, pr *domain.PullRequest, reviewers []string, oldUserID string) (string, error) {
+	oldReviewer, err := s.repo.GetUser(ctx, oldUserID)
+	if err != nil {
+		s.logger.Error("Failed to get old reviewer", "error", err)
+		return "", err
+	}
+
+	teamName, err := s.assigner.replacementTeam(ctx, prTeam, oldReviewer)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer teams", "error", err)
+		return "", err
+	}
+
+	candidates, err := s.repo.GetActiveTeamMembers(ctx, teamName, oldUserID)
+	if err != nil {
+		s.logger.Error("Failed to get team candidates", "error", err)
+		return "", err
+	}
+
+	available := s.filterAvailableReviewers(candidates, pr, reviewers)
+
+	var cause error = domain.ErrNoActiveCandidate
+	if len(available) > 0 {
+		selected, err := s.assigner.selectReviewers(ctx, pr.AuthorID, domain.AssignmentSourceReplacement, teamName, available, 1)
+		if err != nil && err != domain.ErrReviewersAtCapacity {
+			s.logger.Error("Failed to select replacement reviewer", "error", err)
+			return "", err
+		}
+		if len(selected) > 0 {
+			return selected[0].UserID, nil
+		}
+		if err != nil {
+			cause = err
+		}
+	}
+
+	return "", cause
+}
+
+// findAncestorReplacement ищет замену в командах выше команды снимаемого ревьювера;
+// если никого нет и у корня, возвращает исходную ошибку
+func (s *PRService) findAncestorReplacement(ctx context.Context, prTeam string, pr *domain.PullRequest, reviewers []string, oldUserID string, cause error) (string, error) {
+	oldReviewer, err := s.repo.GetUser(ctx, oldUserID)
+	if err != nil {
+		s.logger.Error("Failed to get old reviewer", "error", err)
+		return "", err
+	}
+
+	teamName, err := s.assigner.replacementTeam(ctx, prTeam, oldReviewer)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer teams", "error", err)
+		return "", err
+	}
+
+	exclude := map[string]bool{pr.AuthorID: true, oldUserID: true}
+	for _, r := range reviewers {
+		exclude[r] = true
+	}
+	escalated, err := s.assigner.selectFromAncestors(ctx, pr.AuthorID, teamName, exclude, 1, nil)
+	if err != nil {
+		s.logger.Error("Failed to select replacement from parent teams", "error", err)
+		return "", err
+	}
+	if len(escalated) == 0 {
+		return "", cause
+	}
+
+	return escalated[0].UserID, nil
+}
+
+// needsSeniorReplacement проверяет, что после снятия oldUserID у PR не останется старшего ревьювера,
+// которого требует команда PR
+func (s *PRService) needsSeniorReplacement(ctx context.Context, settings *domain.TeamSettings, reviewers []string, oldUserID string) (bool, error) {
+	if !settings.RequireSenior {
+		return false, nil
+	}
+
+	remaining := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		if r != oldUserID {
+			remaining = append(remaining, r)
+		}
+	}
+
+	hasSenior, err := s.assigner.hasSeniorReviewer(ctx, remaining)
+	if err != nil {
+		s.logger.Error("Failed to check senior reviewers", "error", err)
+		return false, err
+	}
+
+	return !hasSenior, nil
+}
+
+// findSeniorReplacement ищет старшего ревьювера в команде снимаемого ревьювера и команде PR,
+// затем в резервных командах
+func (s *PRService) findSeniorReplacement(ctx context.Context, settings *domain.TeamSettings, prTeam string, pr *domain.PullRequest, reviewers []string, oldUserID string) (string, bool, error) {
+	oldReviewer, err := s.repo.GetUser(ctx, oldUserID)
+	if err != nil {
+		s.logger.Error("Failed to get old reviewer", "error", err)
+		return "", false, err
+	}
+
+	teamName, err := s.assigner.replacementTeam(ctx, prTeam, oldReviewer)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer teams", "error", err)
+		return "", false, err
+	}
+
+	candidates, err := s.assigner.seniorReplacementCandidates(ctx, teamName, oldUserID, prTeam, pr.AuthorID)
+	if err != nil {
+		s.logger.Error("Failed to get team candidates", "error", err)
+		return "", false, err
+	}
+
+	exclude := map[string]bool{pr.AuthorID: true, oldUserID: true}
+	for _, r := range reviewers {
+		exclude[r] = true
+	}
+
+	senior, fromFallback, err := s.assigner.selectSenior(ctx, pr.AuthorID, settings, teamName, candidates, exclude)
+	if err != nil {
+		if err != domain.ErrSeniorReviewerRequired {
+			s.logger.Error("Failed to select senior replacement", "error", err)
+		}
+		return "", false, err
+	}
+
+	return senior.UserID, fromFallback, nil
+}
+
+// findFallbackReplacement ищет замену в резервных командах; если их нет, возвращает исходную ошибку
+func (s *PRService) findFallbackReplacement(ctx context.Context, settings *domain.TeamSettings, pr *domain.PullRequest, reviewers []string, cause error) (string, bool, error) {
+	exclude := map[string]bool{pr.AuthorID: true}
+	for _, r := range reviewers {
+		exclude[r] = true
+	}
+
+	fallback, err := s.assigner.selectFromFallback(ctx, pr.AuthorID, settings, exclude, 1, nil)
+	if err != nil {
+		s.logger.Error("Failed to select fallback reviewer", "error", err)
+		return "", false, err
+	}
+	if len(fallback) == 0 {
+		return "", false, cause
 	}
 
-	return selected
+	return fallback[0].UserID, true, nil
 }
 
 func (s *PRService) filterAvailableReviewers(candidates []domain.User, pr *domain.PullRequest, reviewers []string) []domain.User {
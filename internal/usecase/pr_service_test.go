@@ -2,12 +2,16 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage"
 	"pr-reviewer/internal/infrastructure/storage/memory"
 )
 
@@ -28,6 +32,13 @@ func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(
 	return fn(ctx)
 }
 
+func newTestAssigner(t *testing.T, repo storage.Repository, selectors *ReviewerSelectors, capacityPolicy domain.CapacityPolicy) *ReviewerAssigner {
+	t.Helper()
+	assigner, err := NewReviewerAssigner(repo, selectors, capacityPolicy)
+	require.NoError(t, err)
+	return assigner
+}
+
 func TestPRService_CreatePR(t *testing.T) {
 	repo := memory.NewMemoryRepository()
 	mockLogger := new(MockLogger)
@@ -38,7 +49,7 @@ func TestPRService_CreatePR(t *testing.T) {
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
 
-	service := NewPRService(repo, mockTxManager, mockLogger)
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	team := &domain.Team{TeamName: "backend"}
 	members := []domain.User{
@@ -64,8 +75,8 @@ func TestPRService_CreatePR(t *testing.T) {
 		assert.Equal(t, "Add feature", pr.PullRequestName)
 		assert.Equal(t, "u1", pr.AuthorID)
 		assert.Equal(t, domain.PRStatusOpen, pr.Status)
-		assert.LessOrEqual(t, len(pr.AssignedReviewers), 2)
-		assert.NotContains(t, pr.AssignedReviewers, "u1")
+		assert.LessOrEqual(t, len(pr.ReviewerIDs()), 2)
+		assert.NotContains(t, pr.ReviewerIDs(), "u1")
 	})
 
 	t.Run("returns error when PR already exists", func(t *testing.T) {
@@ -96,54 +107,1333 @@ func TestPRService_CreatePR(t *testing.T) {
 	})
 }
 
-func TestPRService_MergePR(t *testing.T) {
+func TestPRService_CreatePR_TeamSettings(t *testing.T) {
 	repo := memory.NewMemoryRepository()
 	mockLogger := new(MockLogger)
 	mockTxManager := new(MockTransactionManager)
 	ctx := context.TODO()
-	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
 
-	service := NewPRService(repo, mockTxManager, mockLogger)
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
-	team := &domain.Team{TeamName: "backend"}
+	team := &domain.Team{TeamName: "platform"}
 	members := []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "platform", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "platform", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "platform", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "platform", IsActive: true},
+	}
+	err := repo.CreateTeam(ctx, team, members)
+	assert.NoError(t, err)
+
+	t.Run("assigns max_reviewers reviewers", func(t *testing.T) {
+		err := repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "platform", MinReviewers: 1, MaxReviewers: 3})
+		assert.NoError(t, err)
+
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Infra", AuthorID: "u1"})
+		assert.NoError(t, err)
+		assert.Len(t, pr.ReviewerIDs(), 3)
+	})
+
+	t.Run("fails when minimum cannot be met", func(t *testing.T) {
+		err := repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "platform", MinReviewers: 4, MaxReviewers: 4})
+		assert.NoError(t, err)
+
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-2", PullRequestName: "Infra", AuthorID: "u1"})
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrNotEnoughReviewers, err)
+	})
+
+	t.Run("reassign replaces reviewer when PR is above max", func(t *testing.T) {
+		err := repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "platform", MinReviewers: 0, MaxReviewers: 1})
+		assert.NoError(t, err)
+		require.NoError(t, repo.CreateOrUpdateUser(ctx, &domain.User{UserID: "u5", Username: "Eve", TeamName: "platform", IsActive: true}))
+		require.NoError(t, repo.AddTeamMember(ctx, "platform", "u5"))
+
+		_, reviewers, err := repo.GetPRWithReviewers(ctx, "pr-1")
+		assert.NoError(t, err)
+
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: reviewers[0]})
+		require.NoError(t, err)
+		assert.Equal(t, "u5", result.ReplacedBy)
+		assert.Len(t, result.PR.ReviewerIDs(), 3)
+		assert.NotContains(t, result.PR.ReviewerIDs(), reviewers[0])
+	})
+}
+
+func TestPRService_CreatePR_CodeOwners(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
 		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
 		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
-	}
-	repo.CreateTeam(ctx, team, members)
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "dba"}, []domain.User{
+		{UserID: "d1", Username: "Dana", TeamName: "dba", IsActive: true},
+	})
+	assert.NoError(t, err)
 
-	req := domain.CreatePRRequest{
-		PullRequestID:   "pr-merge-test",
-		PullRequestName: "Test PR",
+	rules, err := parseCodeOwners(domain.CodeOwnerScopeTeam, "backend", "/api/ @u4")
+	assert.NoError(t, err)
+	assert.NoError(t, repo.ReplaceCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, "backend", rules))
+
+	rules, err = parseCodeOwners(domain.CodeOwnerScopeRepository, "core", "*.sql @d1")
+	assert.NoError(t, err)
+	assert.NoError(t, repo.ReplaceCodeOwnerRules(ctx, domain.CodeOwnerScopeRepository, "core", rules))
+
+	pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+		PullRequestID:   "pr-owners",
+		PullRequestName: "Schema change",
 		AuthorID:        "u1",
-	}
-	service.CreatePR(ctx, req)
+		Repository:      "core",
+		ChangedFiles:    []string{"db/001.sql", "api/handler.go"},
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"d1", "u4"}, pr.ReviewerIDs())
+}
 
-	t.Run("successfully merges PR", func(t *testing.T) {
-		pr, err := service.MergePR(ctx, "pr-merge-test")
+func TestPRService_CreatePR_FallbackTeams(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "mobile"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "mobile", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "mobile", IsActive: true},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "empty"}, []domain.User{
+		{UserID: "u3", Username: "Charlie", TeamName: "empty", IsActive: false},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	err = repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "mobile", MinReviewers: 2, MaxReviewers: 2, FallbackTeams: []string{"empty", "backend"}})
+	assert.NoError(t, err)
 
+	t.Run("fills missing reviewers from fallback teams", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "App", AuthorID: "u1"})
 		assert.NoError(t, err)
-		assert.NotNil(t, pr)
-		assert.Equal(t, domain.PRStatusMerged, pr.Status)
-		assert.NotNil(t, pr.MergedAt)
+		assert.Len(t, pr.ReviewerIDs(), 2)
+		assert.Contains(t, pr.ReviewerIDs(), "u2")
+		assert.Len(t, pr.FallbackReviewers, 1)
+		assert.Contains(t, []string{"u4", "u5"}, pr.FallbackReviewers[0])
 	})
 
-	t.Run("merge is idempotent", func(t *testing.T) {
-		pr, err := service.MergePR(ctx, "pr-merge-test")
+	t.Run("reassign uses fallback when team has no candidates", func(t *testing.T) {
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"u4", "u5"}, result.ReplacedBy)
+		assert.Equal(t, []string{result.ReplacedBy}, result.PR.FallbackReviewers)
+	})
+}
+
+func TestPRService_CreatePR_MultipleTeams(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	})
+	require.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "frontend"}, []domain.User{
+		{UserID: "f1", Username: "Frank", TeamName: "frontend", IsActive: true},
+		{UserID: "f2", Username: "Grace", TeamName: "frontend", IsActive: true},
+		{UserID: "f3", Username: "Heidi", TeamName: "frontend", IsActive: true},
+	})
+	require.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "mobile"}, []domain.User{
+		{UserID: "m1", Username: "Mallory", TeamName: "mobile", IsActive: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.AddTeamMember(ctx, "frontend", "u1"))
+
+	t.Run("uses primary team without hint", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "API", AuthorID: "u1"})
+		require.NoError(t, err)
+		assert.Equal(t, "backend", pr.TeamName)
+		assert.Equal(t, []string{"u2"}, pr.ReviewerIDs())
+	})
+
+	t.Run("uses team from hint", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-2", PullRequestName: "UI", AuthorID: "u1", TeamName: "frontend"})
+		require.NoError(t, err)
+		assert.Equal(t, "frontend", pr.TeamName)
+		require.Len(t, pr.ReviewerIDs(), 2)
+		assert.Subset(t, []string{"f1", "f2", "f3"}, pr.ReviewerIDs())
+
+		stored, err := repo.GetPR(ctx, "pr-2")
+		require.NoError(t, err)
+		assert.Equal(t, "frontend", stored.TeamName)
+	})
+
+	t.Run("rejects team the author is not a member of", func(t *testing.T) {
+		_, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-3", PullRequestName: "App", AuthorID: "u1", TeamName: "mobile"})
+		assert.Equal(t, domain.ErrAuthorNotInTeam, err)
+	})
+
+	t.Run("reassign picks replacement from PR team", func(t *testing.T) {
+		_, reviewers, err := repo.GetPRWithReviewers(ctx, "pr-2")
+		require.NoError(t, err)
+
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-2", OldUserID: reviewers[0]})
+		require.NoError(t, err)
+		assert.Contains(t, []string{"f1", "f2", "f3"}, result.ReplacedBy)
+		assert.NotContains(t, reviewers, result.ReplacedBy)
+	})
+}
+
+func TestPRService_ParentTeamEscalation(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "dept"}, []domain.User{
+		{UserID: "d1", Username: "Dan", TeamName: "dept", IsActive: true},
+	}))
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "squad-a", ParentTeam: "dept"}, []domain.User{
+		{UserID: "a1", Username: "Alice", TeamName: "squad-a", IsActive: true},
+		{UserID: "a2", Username: "Andy", TeamName: "squad-a", IsActive: true},
+	}))
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "squad-b", ParentTeam: "dept"}, []domain.User{
+		{UserID: "b1", Username: "Bob", TeamName: "squad-b", IsActive: true},
+	}))
+
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "squad-c", ParentTeam: "dept"}, []domain.User{
+		{UserID: "c1", Username: "Carol", TeamName: "squad-c", IsActive: true},
+	}))
+	require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "ops"}, []domain.User{
+		{UserID: "o1", Username: "Oscar", TeamName: "ops", IsActive: true},
+	}))
+	require.NoError(t, repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "squad-c", MinReviewers: 1, MaxReviewers: 2, FallbackTeams: []string{"ops"}}))
+
+	t.Run("does not escalate when team meets minimum", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "API", AuthorID: "a1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a2"}, pr.ReviewerIDs())
+	})
+
+	t.Run("escalates below minimum to nearest teams", func(t *testing.T) {
+		require.NoError(t, repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "squad-a", MinReviewers: 2, MaxReviewers: 2}))
+
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-2", PullRequestName: "DB", AuthorID: "a1"})
+		require.NoError(t, err)
+		require.Len(t, pr.ReviewerIDs(), 2)
+		assert.Contains(t, pr.ReviewerIDs(), "a2")
+		assert.Subset(t, []string{"a2", "d1", "b1", "c1"}, pr.ReviewerIDs())
+		assert.Empty(t, pr.FallbackReviewers)
+
+		trace, err := service.GetAssignmentTrace(ctx, "pr-2")
+		require.NoError(t, err)
+		sources := make([]domain.AssignmentSource, 0)
+		for _, r := range trace.Records {
+			sources = append(sources, r.Source)
+		}
+		assert.Contains(t, sources, domain.AssignmentSourceParentTeam)
+	})
+
+	t.Run("reassign escalates when squad has no candidate", func(t *testing.T) {
+		_, reviewers, err := repo.GetPRWithReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: "a2"})
+		require.NoError(t, err)
+		assert.Contains(t, []string{"d1", "b1", "c1"}, result.ReplacedBy)
+		assert.NotContains(t, reviewers, result.ReplacedBy)
+		assert.Empty(t, result.PR.FallbackReviewers)
+	})
+
+	t.Run("consults fallback teams before parent teams", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-3", PullRequestName: "UI", AuthorID: "c1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"o1"}, pr.ReviewerIDs())
+		assert.Equal(t, []string{"o1"}, pr.FallbackReviewers)
+	})
+
+	t.Run("fails at the root", func(t *testing.T) {
+		for _, userID := range []string{"a2", "d1", "c1"} {
+			require.NoError(t, repo.SetUserActive(ctx, userID, false))
+		}
+
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-4", PullRequestName: "Docs", AuthorID: "b1"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a1"}, pr.ReviewerIDs())
+
+		_, err = service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-4", OldUserID: "a1"})
+		assert.Equal(t, domain.ErrNoActiveCandidate, err)
+	})
+}
+
+func TestPRService_CreatePR_RequiredTags(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, Skills: []string{"frontend"}},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, Skills: []string{"frontend"}},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true, Skills: []string{"sql"}},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true, Skills: []string{"go"}},
+	})
+	assert.NoError(t, err)
+
+	t.Run("covers each required tag", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+				PullRequestID:   fmt.Sprintf("pr-%d", i),
+				PullRequestName: "Migration",
+				AuthorID:        "u1",
+				RequiredTags:    []string{"SQL", "go"},
+			})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []string{"u4", "u5"}, pr.ReviewerIDs())
+			assert.Empty(t, pr.UncoveredTags)
+		}
+	})
 
+	t.Run("reports uncovered tags", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:   "pr-rust",
+			PullRequestName: "Rewrite",
+			AuthorID:        "u1",
+			RequiredTags:    []string{"sql", "rust"},
+		})
 		assert.NoError(t, err)
-		assert.NotNil(t, pr)
-		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+		assert.Len(t, pr.ReviewerIDs(), 2)
+		assert.Contains(t, pr.ReviewerIDs(), "u4")
+		assert.Equal(t, []string{"rust"}, pr.UncoveredTags)
 	})
 
-	t.Run("returns error for nonexistent PR", func(t *testing.T) {
-		pr, err := service.MergePR(ctx, "nonexistent")
+	t.Run("matches tags within PR team only", func(t *testing.T) {
+		require.NoError(t, repo.CreateTeam(ctx, &domain.Team{TeamName: "platform"}, []domain.User{
+			{UserID: "p1", Username: "Peggy", TeamName: "platform", IsActive: true, Skills: []string{"sql"}},
+		}))
+		require.NoError(t, repo.ReplaceCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, "backend", []domain.CodeOwnerRule{
+			{ScopeType: domain.CodeOwnerScopeTeam, ScopeName: "backend", Pattern: "*.sql", Owners: []string{"p1"}},
+		}))
 
-		assert.Error(t, err)
-		assert.Nil(t, pr)
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:   "pr-owner-tag",
+			PullRequestName: "Migration",
+			AuthorID:        "u1",
+			ChangedFiles:    []string{"db/001.sql"},
+			RequiredTags:    []string{"sql"},
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u4", "p1"}, pr.ReviewerIDs())
+
+		trace, err := service.GetAssignmentTrace(ctx, "pr-owner-tag")
+		require.NoError(t, err)
+		sources := make(map[string]domain.AssignmentSource)
+		for _, record := range trace.Records {
+			sources[record.ReviewerID] = record.Source
+		}
+		assert.Equal(t, domain.AssignmentSourceSkillTag, sources["u4"])
+		assert.Equal(t, domain.AssignmentSourceCodeOwner, sources["p1"])
+	})
+}
+
+func TestPRService_AssignmentTrace(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	zero := 0
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: false},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true, MaxOpenReviews: &zero},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true},
+		{UserID: "u6", Username: "Frank", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"})
+	assert.NoError(t, err)
+
+	t.Run("records every assigned reviewer", func(t *testing.T) {
+		trace, err := service.GetAssignmentTrace(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Len(t, trace.Records, 2)
+
+		for _, record := range trace.Records {
+			assert.Contains(t, pr.ReviewerIDs(), record.ReviewerID)
+			assert.Equal(t, domain.AssignmentSourceTeam, record.Source)
+			assert.Equal(t, domain.SelectionStrategyRandom, record.Strategy)
+			assert.Equal(t, 3, record.CandidatePoolSize)
+			assert.False(t, record.AssignedAt.IsZero())
+			assert.Equal(t, []domain.ExcludedCandidate{
+				{UserID: "u1", Reason: domain.ExclusionAuthor},
+				{UserID: "u3", Reason: domain.ExclusionInactive},
+				{UserID: "u4", Reason: domain.ExclusionAtCapacity},
+			}, record.Excluded)
+		}
+	})
+
+	t.Run("records replacement", func(t *testing.T) {
+		oldReviewer, keptReviewer := pr.ReviewerIDs()[0], pr.ReviewerIDs()[1]
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: oldReviewer})
+		assert.NoError(t, err)
+
+		trace, err := service.GetAssignmentTrace(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Len(t, trace.Records, 3)
+
+		record := trace.Records[2]
+		assert.Equal(t, result.ReplacedBy, record.ReviewerID)
+		assert.Equal(t, domain.AssignmentSourceReplacement, record.Source)
+		assert.Equal(t, 1, record.CandidatePoolSize)
+		assert.Contains(t, record.Excluded, domain.ExcludedCandidate{UserID: oldReviewer, Reason: domain.ExclusionAlreadyAssigned})
+		assert.Contains(t, record.Excluded, domain.ExcludedCandidate{UserID: keptReviewer, Reason: domain.ExclusionAlreadyAssigned})
+	})
+
+	t.Run("returns error for unknown PR", func(t *testing.T) {
+		_, err := service.GetAssignmentTrace(ctx, "unknown")
+		assert.Equal(t, domain.ErrPRNotFound, err)
+	})
+}
+
+func TestPRService_Preview(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	selectors, err := NewReviewerSelectors(repo, domain.SelectionStrategyRoundRobin, nil, DefaultPairingHistoryOptions())
+	assert.NoError(t, err)
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, selectors, domain.CapacityPolicyFewer), mockLogger)
+
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+	err = repo.SaveTeamSettings(ctx, &domain.TeamSettings{TeamName: "backend", MaxReviewers: 1})
+	assert.NoError(t, err)
+
+	req := domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"}
+
+	t.Run("preview does not write and does not advance round robin", func(t *testing.T) {
+		first, err := service.PreviewPR(ctx, req)
+		assert.NoError(t, err)
+		second, err := service.PreviewPR(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, first.ReviewerIDs(), second.ReviewerIDs())
+
+		exists, err := repo.PRExists(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		created, err := service.CreatePR(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, first.ReviewerIDs(), created.ReviewerIDs())
+	})
+
+	t.Run("reassign preview keeps reviewers", func(t *testing.T) {
+		reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+		assert.NoError(t, err)
+		oldReviewer := reviewers[0]
+
+		result, err := service.PreviewReassign(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: oldReviewer})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.ReplacedBy)
+		assert.Equal(t, []string{result.ReplacedBy}, result.PR.ReviewerIDs())
+
+		assigned, err := repo.IsReviewerAssigned(ctx, "pr-1", oldReviewer)
+		assert.NoError(t, err)
+		assert.True(t, assigned)
+
+		trace, err := service.GetAssignmentTrace(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Len(t, trace.Records, 1)
+	})
+}
+
+func TestPRService_CreatePR_RequestedReviewers(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: false},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "frontend"}, []domain.User{
+		{UserID: "f1", Username: "Frank", TeamName: "frontend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	t.Run("assigns requested first and never picks excluded", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+				PullRequestID:      fmt.Sprintf("pr-%d", i),
+				PullRequestName:    "Feature",
+				AuthorID:           "u1",
+				RequestedReviewers: []string{"f1"},
+				ExcludedReviewers:  []string{"u2", "u3"},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"f1", "u4"}, pr.ReviewerIDs())
+		}
+
+		trace, err := service.GetAssignmentTrace(ctx, "pr-0")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AssignmentSourceRequested, trace.Records[0].Source)
+		assert.Contains(t, trace.Records[1].Excluded, domain.ExcludedCandidate{UserID: "u2", Reason: domain.ExclusionExcluded})
+	})
+
+	t.Run("validates requested reviewers", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			req      domain.CreatePRRequest
+			expected error
+		}{
+			{"unknown user", domain.CreatePRRequest{RequestedReviewers: []string{"nobody"}}, domain.ErrRequestedReviewerNotFound},
+			{"inactive user", domain.CreatePRRequest{RequestedReviewers: []string{"u5"}}, domain.ErrRequestedReviewerInactive},
+			{"author", domain.CreatePRRequest{RequestedReviewers: []string{"u1"}}, domain.ErrRequestedReviewerIsAuthor},
+			{"requested and excluded", domain.CreatePRRequest{RequestedReviewers: []string{"u2"}, ExcludedReviewers: []string{"u2"}}, domain.ErrRequestedReviewerExcluded},
+			{"too many", domain.CreatePRRequest{RequestedReviewers: []string{"u2", "u3", "u4"}}, domain.ErrTooManyRequestedReviewers},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.req.PullRequestID = "pr-invalid"
+				tc.req.PullRequestName = "Invalid"
+				tc.req.AuthorID = "u1"
+
+				pr, err := service.CreatePR(ctx, tc.req)
+				assert.Nil(t, pr)
+				assert.Equal(t, tc.expected, err)
+			})
+		}
+	})
+}
+
+func TestPRService_SeniorRequired(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, Level: domain.UserLevelJunior},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, Level: domain.UserLevelJunior},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, Level: domain.UserLevelMiddle},
+		{UserID: "s1", Username: "Sam", TeamName: "backend", IsActive: true, Level: domain.UserLevelSenior},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "platform"}, []domain.User{
+		{UserID: "l1", Username: "Lena", TeamName: "platform", IsActive: true, Level: domain.UserLevelLead},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "mobile"}, []domain.User{
+		{UserID: "m1", Username: "Max", TeamName: "mobile", IsActive: true, Level: domain.UserLevelJunior},
+		{UserID: "m2", Username: "Mia", TeamName: "mobile", IsActive: true, Level: domain.UserLevelJunior},
+	})
+	assert.NoError(t, err)
+
+	for _, teamName := range []string{"backend", "mobile"} {
+		settings := domain.DefaultTeamSettings(teamName)
+		settings.RequireSenior = true
+		if teamName == "backend" {
+			settings.FallbackTeams = []string{"platform"}
+		}
+		assert.NoError(t, repo.SaveTeamSettings(ctx, settings))
+	}
+
+	t.Run("always assigns a senior", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+				PullRequestID:   fmt.Sprintf("pr-%d", i),
+				PullRequestName: "Feature",
+				AuthorID:        "u1",
+			})
+			assert.NoError(t, err)
+			assert.Contains(t, pr.ReviewerIDs(), "s1")
+			assert.Len(t, pr.ReviewerIDs(), 2)
+		}
+	})
+
+	t.Run("rejects PR when no senior is available", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:   "pr-mobile",
+			PullRequestName: "Feature",
+			AuthorID:        "m1",
+		})
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrSeniorReviewerRequired, err)
+	})
+
+	t.Run("rejects requested juniors that leave no room for a senior", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:      "pr-juniors",
+			PullRequestName:    "Feature",
+			AuthorID:           "u1",
+			RequestedReviewers: []string{"u2", "u3"},
+		})
+		assert.Nil(t, pr)
+		assert.Equal(t, domain.ErrSeniorReviewerRequired, err)
+	})
+
+	t.Run("replaces the only senior with a senior", func(t *testing.T) {
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-0", OldUserID: "s1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "l1", result.ReplacedBy)
+		assert.Equal(t, []string{"l1"}, result.PR.FallbackReviewers)
+
+		result, err = service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-0", OldUserID: "l1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "s1", result.ReplacedBy)
+	})
+
+	t.Run("reports violation on reassign", func(t *testing.T) {
+		assert.NoError(t, repo.SetUserActive(ctx, "l1", false))
+
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: "s1"})
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrSeniorReviewerRequired, err)
+
+		reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Contains(t, reviewers, "s1")
+	})
+}
+
+func TestPRService_CreatePR_SizeAwareReviewerCount(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	settings := domain.DefaultTeamSettings("backend")
+	settings.MinReviewers = 2
+	settings.ReviewersBySize = map[domain.PRSize]int{
+		domain.PRSizeXS: 1,
+		domain.PRSizeS:  1,
+		domain.PRSizeXL: 3,
+	}
+	assert.NoError(t, repo.SaveTeamSettings(ctx, settings))
+
+	tests := []struct {
+		name      string
+		req       domain.CreatePRRequest
+		size      domain.PRSize
+		reviewers int
+	}{
+		{"small PR by lines", domain.CreatePRRequest{LinesAdded: 30, LinesDeleted: 10}, domain.PRSizeS, 1},
+		{"medium PR uses max_reviewers", domain.CreatePRRequest{LinesAdded: 100}, domain.PRSizeM, 2},
+		{"large PR by lines", domain.CreatePRRequest{LinesAdded: 900, LinesDeleted: 300}, domain.PRSizeXL, 3},
+		{"explicit size wins over lines", domain.CreatePRRequest{LinesAdded: 5, Size: domain.PRSizeXL}, domain.PRSizeXL, 3},
+		{"unknown size", domain.CreatePRRequest{}, "", 2},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.PullRequestID = fmt.Sprintf("pr-%d", i)
+			tt.req.PullRequestName = "Feature"
+			tt.req.AuthorID = "u1"
+
+			pr, err := service.CreatePR(ctx, tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.size, pr.Size)
+			assert.Len(t, pr.ReviewerIDs(), tt.reviewers)
+
+			stored, err := repo.GetPR(ctx, tt.req.PullRequestID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.size, stored.Size)
+		})
+	}
+
+	t.Run("rejects invalid size", func(t *testing.T) {
+		for _, req := range []domain.CreatePRRequest{
+			{PullRequestID: "pr-bad-size", PullRequestName: "Bad", AuthorID: "u1", Size: "huge"},
+			{PullRequestID: "pr-bad-lines", PullRequestName: "Bad", AuthorID: "u1", LinesDeleted: -1},
+		} {
+			pr, err := service.CreatePR(ctx, req)
+			assert.Nil(t, pr)
+			assert.Equal(t, domain.ErrInvalidPRSize, err)
+		}
+	})
+
+	t.Run("reassign keeps size-based count", func(t *testing.T) {
+		reviewers, err := repo.GetPRReviewers(ctx, "pr-0")
+		assert.NoError(t, err)
+		assert.Len(t, reviewers, 1)
+		oldReviewer := reviewers[0]
+
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-0", OldUserID: oldReviewer})
+		assert.NoError(t, err)
+		assert.Len(t, result.PR.ReviewerIDs(), 1)
+		assert.NotEmpty(t, result.ReplacedBy)
+	})
+
+	t.Run("classifies by team thresholds", func(t *testing.T) {
+		settings.SizeThresholds = map[domain.PRSize]int{domain.PRSizeXS: 5, domain.PRSizeS: 20, domain.PRSizeM: 100, domain.PRSizeL: 300}
+		require.NoError(t, repo.SaveTeamSettings(ctx, settings))
+		defer func() {
+			settings.SizeThresholds = domain.DefaultPRSizeThresholds()
+			require.NoError(t, repo.SaveTeamSettings(ctx, settings))
+		}()
+
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-thresholds", PullRequestName: "Feature", AuthorID: "u1", LinesAdded: 400})
+		require.NoError(t, err)
+		assert.Equal(t, domain.PRSizeXL, pr.Size)
+		assert.Len(t, pr.ReviewerIDs(), 3)
+	})
+}
+
+func TestPRService_MergePR(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	team := &domain.Team{TeamName: "backend"}
+	members := []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}
+	repo.CreateTeam(ctx, team, members)
+
+	req := domain.CreatePRRequest{
+		PullRequestID:   "pr-merge-test",
+		PullRequestName: "Test PR",
+		AuthorID:        "u1",
+	}
+	service.CreatePR(ctx, req)
+
+	t.Run("successfully merges PR", func(t *testing.T) {
+		pr, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-merge-test"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, pr)
+		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+		assert.NotNil(t, pr.MergedAt)
+	})
+
+	t.Run("merge is idempotent", func(t *testing.T) {
+		pr, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-merge-test"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, pr)
+		assert.Equal(t, domain.PRStatusMerged, pr.Status)
+	})
+
+	t.Run("returns error for nonexistent PR", func(t *testing.T) {
+		pr, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "nonexistent"})
+
+		assert.Error(t, err)
+		assert.Nil(t, pr)
+	})
+}
+
+func TestPRService_SubmitReview(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	created, err := service.CreatePR(ctx, domain.CreatePRRequest{
+		PullRequestID:      "pr-1",
+		PullRequestName:    "Feature",
+		AuthorID:           "u1",
+		RequestedReviewers: []string{"u2", "u3"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ReviewerStatus{
+		{UserID: "u2", State: domain.ReviewStatePending},
+		{UserID: "u3", State: domain.ReviewStatePending},
+	}, created.AssignedReviewers)
+
+	t.Run("records reviewer state", func(t *testing.T) {
+		pr, err := service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: domain.ReviewStateChangesRequested})
+		assert.NoError(t, err)
+		pr, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: domain.ReviewStateApproved})
+		assert.NoError(t, err)
+
+		for _, reviewer := range pr.AssignedReviewers {
+			switch reviewer.UserID {
+			case "u2":
+				assert.Equal(t, domain.ReviewStateApproved, reviewer.State)
+				assert.NotNil(t, reviewer.ReviewedAt)
+			case "u3":
+				assert.Equal(t, domain.ReviewStatePending, reviewer.State)
+				assert.Nil(t, reviewer.ReviewedAt)
+			}
+		}
+	})
+
+	t.Run("validates request", func(t *testing.T) {
+		_, err := service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: domain.ReviewStatePending})
+		assert.Equal(t, domain.ErrInvalidReviewState, err)
+
+		_, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u4", State: domain.ReviewStateApproved})
+		assert.Equal(t, domain.ErrReviewerNotAssigned, err)
+
+		_, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "unknown", ReviewerID: "u2", State: domain.ReviewStateApproved})
+		assert.Equal(t, domain.ErrPRNotFound, err)
+	})
+
+	t.Run("replacement starts pending", func(t *testing.T) {
+		result, err := service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+		assert.NoError(t, err)
+		assert.Contains(t, result.PR.AssignedReviewers, domain.ReviewerStatus{UserID: "u4", State: domain.ReviewStatePending})
+	})
+
+	t.Run("merged PR keeps states and rejects reviews", func(t *testing.T) {
+		_, err := service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u3", State: domain.ReviewStateCommented})
+		assert.NoError(t, err)
+
+		merged, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-1"})
+		assert.NoError(t, err)
+		assert.Contains(t, merged.ReviewerIDs(), "u3")
+		for _, reviewer := range merged.AssignedReviewers {
+			if reviewer.UserID == "u3" {
+				assert.Equal(t, domain.ReviewStateCommented, reviewer.State)
+			}
+		}
+
+		_, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u3", State: domain.ReviewStateApproved})
+		assert.Equal(t, domain.ErrReviewOnMergedPR, err)
+	})
+}
+
+func TestPRService_DraftAndClosed(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	t.Run("draft has no reviewers until ready", func(t *testing.T) {
+		pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-draft", PullRequestName: "WIP", AuthorID: "u1", Draft: true})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusDraft, pr.Status)
+		assert.Empty(t, pr.AssignedReviewers)
+
+		_, err = service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-draft"})
+		appErr, ok := err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrCodeInvalidTransition, appErr.Code)
+
+		pr, err = service.MarkReady(ctx, domain.MarkReadyRequest{PullRequestID: "pr-draft", RequestedReviewers: []string{"u3"}})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, pr.ReviewerIDs())
+
+		trace, err := service.GetAssignmentTrace(ctx, "pr-draft")
+		assert.NoError(t, err)
+		assert.Len(t, trace.Records, 2)
+
+		_, err = service.MarkReady(ctx, domain.MarkReadyRequest{PullRequestID: "pr-draft"})
+		appErr, ok = err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrCodeInvalidTransition, appErr.Code)
+	})
+
+	t.Run("closed PR releases reviewer load", func(t *testing.T) {
+		_, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-closed", PullRequestName: "Abandoned", AuthorID: "u1"})
+		assert.NoError(t, err)
+
+		pr, err := service.ClosePR(ctx, domain.ClosePRRequest{PullRequestID: "pr-closed"})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusClosed, pr.Status)
+
+		// Учитывается только открытый pr-draft
+		counts, err := repo.GetOpenReviewCounts(ctx, []string{"u2", "u3"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"u2": 1, "u3": 1}, counts)
+
+		_, err = service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-closed", OldUserID: "u2"})
+		assert.Equal(t, domain.ErrPRNotOpen, err)
+
+		_, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-closed", ReviewerID: "u2", State: domain.ReviewStateApproved})
+		assert.Equal(t, domain.ErrPRNotOpen, err)
+
+		_, err = service.ClosePR(ctx, domain.ClosePRRequest{PullRequestID: "pr-closed"})
+		appErr, ok := err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrCodeInvalidTransition, appErr.Code)
+	})
+
+	t.Run("reopen drops inactive reviewers", func(t *testing.T) {
+		assert.NoError(t, repo.SetUserActive(ctx, "u2", false))
+
+		pr, err := service.ReopenPR(ctx, domain.ReopenPRRequest{PullRequestID: "pr-closed"})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+		assert.Equal(t, []string{"u3"}, pr.ReviewerIDs())
+
+		_, err = service.ReopenPR(ctx, domain.ReopenPRRequest{PullRequestID: "pr-closed"})
+		appErr, ok := err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrCodeInvalidTransition, appErr.Code)
+	})
+
+	t.Run("merged PR cannot be closed", func(t *testing.T) {
+		_, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-closed"})
+		assert.NoError(t, err)
+
+		_, err = service.ClosePR(ctx, domain.ClosePRRequest{PullRequestID: "pr-closed"})
+		appErr, ok := err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrCodeInvalidTransition, appErr.Code)
+	})
+
+	t.Run("draft keeps exclusions until ready", func(t *testing.T) {
+		assert.NoError(t, repo.SetUserActive(ctx, "u2", true))
+
+		_, err := service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:     "pr-draft-excluded",
+			PullRequestName:   "WIP",
+			AuthorID:          "u1",
+			ExcludedReviewers: []string{"u2"},
+			Draft:             true,
+		})
+		assert.NoError(t, err)
+
+		pr, err := service.MarkReady(ctx, domain.MarkReadyRequest{PullRequestID: "pr-draft-excluded"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, pr.ReviewerIDs())
+	})
+
+	t.Run("draft validates requested reviewers", func(t *testing.T) {
+		_, err := service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:      "pr-draft-invalid",
+			PullRequestName:    "WIP",
+			AuthorID:           "u1",
+			RequestedReviewers: []string{"u2"},
+			ExcludedReviewers:  []string{"u2"},
+			Draft:              true,
+		})
+		assert.Equal(t, domain.ErrRequestedReviewerExcluded, err)
+
+		_, err = service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:      "pr-draft-invalid",
+			PullRequestName:    "WIP",
+			AuthorID:           "u1",
+			RequestedReviewers: []string{"ghost"},
+			Draft:              true,
+		})
+		assert.Equal(t, domain.ErrRequestedReviewerNotFound, err)
+
+		exists, err := repo.PRExists(ctx, "pr-draft-invalid")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestPRService_ReopenReplacesInactiveReviewers(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.CreatePR(ctx, domain.CreatePRRequest{
+		PullRequestID:      "pr-1",
+		PullRequestName:    "Feature",
+		AuthorID:           "u1",
+		RequestedReviewers: []string{"u2", "u3"},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.ClosePR(ctx, domain.ClosePRRequest{PullRequestID: "pr-1"})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.SetUserActive(ctx, "u2", false))
+
+	pr, err := service.ReopenPR(ctx, domain.ReopenPRRequest{PullRequestID: "pr-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PRStatusOpen, pr.Status)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.ReviewerIDs())
+
+	history, err := service.GetHistory(ctx, "pr-1")
+	assert.NoError(t, err)
+	last := history.Events[len(history.Events)-1]
+	assert.Equal(t, domain.PREventReassigned, last.Type)
+	assert.Equal(t, "u2", last.ReviewerID)
+	assert.Equal(t, "u4", last.NewReviewerID)
+	assert.Equal(t, domain.PREventReasonInactive, last.Reason)
+}
+
+func TestPRService_MergePolicy(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+	err = repo.CreateTeam(ctx, &domain.Team{TeamName: "security"}, []domain.User{
+		{UserID: "s1", Username: "Sam", TeamName: "security", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	settings := domain.DefaultTeamSettings("backend")
+	settings.MergePolicy = domain.MergePolicy{MinApprovals: 2, NoChangesRequested: true, RequiredApprovalTeam: "security"}
+	assert.NoError(t, repo.SaveTeamSettings(ctx, settings))
+
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		_, err = service.CreatePR(ctx, domain.CreatePRRequest{
+			PullRequestID:      prID,
+			PullRequestName:    "Feature",
+			AuthorID:           "u1",
+			RequestedReviewers: []string{"u2", "u3"},
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("lists unmet conditions", func(t *testing.T) {
+		_, err := service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: domain.ReviewStateApproved})
+		assert.NoError(t, err)
+		_, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u3", State: domain.ReviewStateChangesRequested})
+		assert.NoError(t, err)
+
+		_, err = service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-1"})
+		appErr, ok := err.(*domain.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrCodeMergeBlocked, appErr.Code)
+		assert.Equal(t, []string{
+			"changes requested by u3",
+			"1 of 2 required approvals",
+			"approval from team security required",
+		}, appErr.Details)
+
+		pr, err := repo.GetPR(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusOpen, pr.Status)
+	})
+
+	t.Run("merges when policy is satisfied", func(t *testing.T) {
+		assert.NoError(t, repo.AddReviewer(ctx, "pr-1", "s1", time.Now()))
+		for _, reviewerID := range []string{"u3", "s1"} {
+			_, err := service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: reviewerID, State: domain.ReviewStateApproved})
+			assert.NoError(t, err)
+		}
+
+		merged, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusMerged, merged.Status)
+
+		events, err := repo.GetPREvents(ctx, "pr-1")
+		assert.NoError(t, err)
+		merge := events[len(events)-1]
+		assert.Equal(t, domain.PREventMerged, merge.Type)
+		assert.False(t, merge.Forced)
+	})
+
+	t.Run("force overrides policy and is recorded", func(t *testing.T) {
+		merged, err := service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-2", Force: true})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PRStatusMerged, merged.Status)
+
+		events, err := repo.GetPREvents(ctx, "pr-2")
+		assert.NoError(t, err)
+		merge := events[len(events)-1]
+		assert.Equal(t, domain.PREventMerged, merge.Type)
+		assert.True(t, merge.Forced)
+		assert.Equal(t, []string{"0 of 2 required approvals", "approval from team security required"}, merge.Conditions)
+	})
+
+	t.Run("repeated merge is not recorded again", func(t *testing.T) {
+		before, err := repo.GetPREvents(ctx, "pr-2")
+		assert.NoError(t, err)
+
+		_, err = service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-2"})
+		assert.NoError(t, err)
+
+		events, err := repo.GetPREvents(ctx, "pr-2")
+		assert.NoError(t, err)
+		assert.Len(t, events, len(before))
+	})
+}
+
+func TestPRService_History(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	pr, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1", RequestedReviewers: []string{"u2", "u3"}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.ReviewerIDs())
+
+	_, err = service.ReassignReviewer(ctx, domain.ReassignRequest{PullRequestID: "pr-1", OldUserID: "u2"})
+	assert.NoError(t, err)
+
+	_, err = service.ClosePR(ctx, domain.ClosePRRequest{PullRequestID: "pr-1"})
+	assert.NoError(t, err)
+
+	t.Run("records events in order", func(t *testing.T) {
+		history, err := service.GetHistory(ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "pr-1", history.PullRequestID)
+
+		types := make([]domain.PREventType, len(history.Events))
+		for i, e := range history.Events {
+			types[i] = e.Type
+		}
+		assert.Equal(t, []domain.PREventType{
+			domain.PREventCreated,
+			domain.PREventReviewerAssigned,
+			domain.PREventReviewerAssigned,
+			domain.PREventReassigned,
+			domain.PREventStateChanged,
+		}, types)
+
+		reassigned := history.Events[3]
+		assert.Equal(t, "u2", reassigned.ReviewerID)
+		assert.Equal(t, "u4", reassigned.NewReviewerID)
+		assert.Equal(t, domain.PREventReasonManual, reassigned.Reason)
+
+		closed := history.Events[4]
+		assert.Equal(t, domain.PRStatusOpen, closed.FromStatus)
+		assert.Equal(t, domain.PRStatusClosed, closed.ToStatus)
+	})
+
+	t.Run("returns error for unknown PR", func(t *testing.T) {
+		_, err := service.GetHistory(ctx, "missing")
+		assert.Equal(t, domain.ErrPRNotFound, err)
+	})
+}
+
+func TestPRService_UpdatePR(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTxManager := new(MockTransactionManager)
+	ctx := context.TODO()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "David", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1", RequestedReviewers: []string{"u2", "u3"}, Labels: []string{"Bug"}})
+	assert.NoError(t, err)
+
+	t.Run("renames and relabels", func(t *testing.T) {
+		pr, err := service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", PullRequestName: "Feature v2", Labels: []string{"bug", "Urgent"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Feature v2", pr.PullRequestName)
+		assert.Equal(t, []string{"bug", "urgent"}, pr.Labels)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, pr.ReviewerIDs())
+
+		pr, err = service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", Labels: []string{}})
+		assert.NoError(t, err)
+		assert.Empty(t, pr.Labels)
+		assert.Equal(t, "Feature v2", pr.PullRequestName)
+	})
+
+	t.Run("new author is replaced as reviewer", func(t *testing.T) {
+		pr, err := service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", AuthorID: "u2"})
+		assert.NoError(t, err)
+		assert.Equal(t, "u2", pr.AuthorID)
+		assert.NotContains(t, pr.ReviewerIDs(), "u2")
+		assert.Len(t, pr.ReviewerIDs(), 2)
+
+		events, err := repo.GetPREvents(ctx, "pr-1")
+		assert.NoError(t, err)
+		updated, reassigned := events[len(events)-2], events[len(events)-1]
+		assert.Equal(t, domain.PREventUpdated, updated.Type)
+		assert.Equal(t, []string{"author_id"}, updated.Fields)
+		assert.Equal(t, "u1", updated.FromAuthorID)
+		assert.Equal(t, "u2", updated.ToAuthorID)
+		assert.Equal(t, domain.PREventReassigned, reassigned.Type)
+		assert.Equal(t, "u2", reassigned.ReviewerID)
+		assert.Equal(t, domain.PREventReasonAuthorChange, reassigned.Reason)
+	})
+
+	t.Run("new author is removed when there is no replacement", func(t *testing.T) {
+		_, err := service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-2", PullRequestName: "Small", AuthorID: "u1", RequestedReviewers: []string{"u2", "u3"}})
+		assert.NoError(t, err)
+
+		// Прежний автор мог бы стать заменой, поэтому свободных кандидатов не оставляем
+		assert.NoError(t, repo.SetUserActive(ctx, "u1", false))
+		assert.NoError(t, repo.SetUserActive(ctx, "u4", false))
+		defer func() {
+			assert.NoError(t, repo.SetUserActive(ctx, "u1", true))
+			assert.NoError(t, repo.SetUserActive(ctx, "u4", true))
+		}()
+
+		pr, err := service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-2", AuthorID: "u3"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, pr.ReviewerIDs())
+	})
+
+	t.Run("author from another team reselects reviewers by its rules", func(t *testing.T) {
+		err := repo.CreateTeam(ctx, &domain.Team{TeamName: "frontend"}, []domain.User{
+			{UserID: "f1", Username: "Frank", TeamName: "frontend", IsActive: true},
+			{UserID: "f2", Username: "Fiona", TeamName: "frontend", IsActive: true},
+			{UserID: "f3", Username: "Felix", TeamName: "frontend", IsActive: true, Level: domain.UserLevelSenior},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, repo.AddTeamMember(ctx, "frontend", "u3"))
+		settings := domain.DefaultTeamSettings("frontend")
+		settings.RequireSenior = true
+		assert.NoError(t, repo.SaveTeamSettings(ctx, settings))
+
+		_, err = service.CreatePR(ctx, domain.CreatePRRequest{PullRequestID: "pr-3", PullRequestName: "Move", AuthorID: "u1", RequestedReviewers: []string{"u2", "u3"}})
+		assert.NoError(t, err)
+		_, err = service.SubmitReview(ctx, domain.SubmitReviewRequest{PullRequestID: "pr-3", ReviewerID: "u3", State: domain.ReviewStateApproved})
+		assert.NoError(t, err)
+
+		pr, err := service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-3", AuthorID: "f1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "frontend", pr.TeamName)
+		assert.ElementsMatch(t, []string{"u3", "f3"}, pr.ReviewerIDs())
+		for _, reviewer := range pr.AssignedReviewers {
+			if reviewer.UserID == "u3" {
+				assert.Equal(t, domain.ReviewStateApproved, reviewer.State)
+			}
+		}
+
+		events, err := repo.GetPREvents(ctx, "pr-3")
+		assert.NoError(t, err)
+		reassigned := events[len(events)-1]
+		assert.Equal(t, domain.PREventReassigned, reassigned.Type)
+		assert.Equal(t, "u2", reassigned.ReviewerID)
+		assert.Equal(t, "f3", reassigned.NewReviewerID)
+		assert.Equal(t, domain.PREventReasonTeamChange, reassigned.Reason)
+	})
+
+	t.Run("rejects invalid updates", func(t *testing.T) {
+		_, err := service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", AuthorID: "missing"})
+		assert.Equal(t, domain.ErrUserNotFound, err)
+
+		_, err = service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", Labels: []string{""}})
+		assert.Equal(t, domain.ErrInvalidLabel, err)
+
+		_, err = service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-1"})
+		assert.NoError(t, err)
+		_, err = service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", AuthorID: "u3"})
+		assert.Equal(t, domain.ErrAuthorChangeOnMergedPR, err)
+
+		pr, err := service.UpdatePR(ctx, domain.UpdatePRRequest{PullRequestID: "pr-1", Labels: []string{"released"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"released"}, pr.Labels)
 	})
 }
 
@@ -157,7 +1447,7 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
 
-	service := NewPRService(repo, mockTxManager, mockLogger)
+	service := NewPRService(repo, mockTxManager, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	team := &domain.Team{TeamName: "backend"}
 	members := []domain.User{
@@ -176,11 +1466,11 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 	prCreated, _ := service.CreatePR(ctx, req)
 
 	t.Run("successfully reassigns reviewer", func(t *testing.T) {
-		if len(prCreated.AssignedReviewers) == 0 {
+		if len(prCreated.ReviewerIDs()) == 0 {
 			t.Skip("No reviewers assigned")
 		}
 
-		oldReviewer := prCreated.AssignedReviewers[0]
+		oldReviewer := prCreated.ReviewerIDs()[0]
 		reassignReq := domain.ReassignRequest{
 			PullRequestID: "pr-reassign-test",
 			OldUserID:     oldReviewer,
@@ -194,12 +1484,12 @@ func TestPRService_ReassignReviewer(t *testing.T) {
 			return
 		}
 		assert.NotNil(t, result)
-		assert.NotContains(t, result.PR.AssignedReviewers, oldReviewer)
+		assert.NotContains(t, result.PR.ReviewerIDs(), oldReviewer)
 		assert.NotEmpty(t, result.ReplacedBy)
 	})
 
 	t.Run("returns error for merged PR", func(t *testing.T) {
-		service.MergePR(ctx, "pr-reassign-test")
+		service.MergePR(ctx, domain.MergePRRequest{PullRequestID: "pr-reassign-test"})
 
 		reassignReq := domain.ReassignRequest{
 			PullRequestID: "pr-reassign-test",
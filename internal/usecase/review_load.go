@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// Запланированные, но еще не записанные изменения нагрузки ревьюверов.
+// Нужны при массовом переназначении, чтобы стратегии видели уже распределенные PR.
+type pendingLoadKey struct{}
+
+type pendingLoad map[string]int
+
+func withPendingLoad(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pendingLoadKey{}, pendingLoad{})
+}
+
+func addPendingLoad(ctx context.Context, userID string, delta int) {
+	if load, ok := ctx.Value(pendingLoadKey{}).(pendingLoad); ok && userID != "" {
+		load[userID] += delta
+	}
+}
+
+// openReviewLoad возвращает число OPEN PR на ревью у пользователей с учетом запланированных изменений
+func openReviewLoad(ctx context.Context, repo storage.Repository, userIDs []string) (map[string]int, error) {
+	counts, err := repo.GetOpenReviewCounts(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if load, ok := ctx.Value(pendingLoadKey{}).(pendingLoad); ok {
+		for _, userID := range userIDs {
+			counts[userID] += load[userID]
+		}
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/logger"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// Notifier доставляет уведомления о ревью, просроченных по SLA
+type Notifier interface {
+	NotifyReviewOverdue(ctx context.Context, review domain.PendingReview, deadline time.Time) error
+}
+
+// LogNotifier пишет уведомления в лог, пока нет внешнего канала доставки
+type LogNotifier struct {
+	logger logger.Logger
+}
+
+func NewLogNotifier(logger logger.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) NotifyReviewOverdue(ctx context.Context, review domain.PendingReview, deadline time.Time) error {
+	n.logger.Warn("Review SLA breached", "pr_id", review.PullRequestID, "reviewer_id", review.ReviewerID, "deadline", deadline)
+	return nil
+}
+
+// ReviewSLAJob периодически ищет назначения на открытые PR, по которым ревьювер не отреагировал
+// в срок SLA команды автора, и эскалирует их. Время берется из now, чтобы его можно было подменить.
+type ReviewSLAJob struct {
+	repo     storage.Repository
+	prs      *PRService
+	notifier Notifier
+	interval time.Duration
+	logger   logger.Logger
+	now      func() time.Time
+}
+
+func NewReviewSLAJob(repo storage.Repository, prs *PRService, notifier Notifier, interval time.Duration, logger logger.Logger) *ReviewSLAJob {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	return &ReviewSLAJob{
+		repo:     repo,
+		prs:      prs,
+		notifier: notifier,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Run выполняет проверку сразу и затем с заданным интервалом до отмены ctx
+func (j *ReviewSLAJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.RunOnce(ctx); err != nil {
+			j.logger.Error("Review SLA job failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *ReviewSLAJob) RunOnce(ctx context.Context) error {
+	now := j.now()
+
+	reviews, err := j.repo.GetPendingReviews(ctx)
+	if err != nil {
+		return err
+	}
+
+	slas := make(map[string]domain.ReviewSLA)
+	for _, review := range reviews {
+		sla, err := j.teamSLA(ctx, slas, review)
+		if err != nil {
+			j.logger.Error("Failed to get review SLA", "pr_id", review.PullRequestID, "error", err)
+			continue
+		}
+
+		deadline := sla.Deadline(review.AssignedAt)
+		if !sla.Enabled() || now.Before(deadline) {
+			continue
+		}
+
+		action := sla.Action
+		if action == "" {
+			action = domain.SLAActionNotify
+		}
+
+		event, err := j.prs.EscalateReview(ctx, review, action, now)
+		if err != nil {
+			j.logger.Error("Failed to escalate overdue review", "pr_id", review.PullRequestID, "reviewer_id", review.ReviewerID, "error", err)
+			continue
+		}
+
+		if event.Reason == string(domain.SLAActionNotify) {
+			if err := j.notifier.NotifyReviewOverdue(ctx, review, deadline); err != nil {
+				j.logger.Error("Failed to send overdue review notification", "pr_id", review.PullRequestID, "error", err)
+			}
+		}
+
+		j.logger.Info("Escalated overdue review", "pr_id", review.PullRequestID, "reviewer_id", review.ReviewerID, "action", event.Reason)
+	}
+
+	return nil
+}
+
+// teamSLA возвращает SLA команды PR, кэшируя его на время одного прохода
+func (j *ReviewSLAJob) teamSLA(ctx context.Context, cache map[string]domain.ReviewSLA, review domain.PendingReview) (domain.ReviewSLA, error) {
+	teamName, err := j.prs.assigner.prTeam(ctx, &domain.PullRequest{AuthorID: review.AuthorID, TeamName: review.TeamName})
+	if err != nil {
+		return domain.ReviewSLA{}, err
+	}
+
+	if sla, ok := cache[teamName]; ok {
+		return sla, nil
+	}
+
+	settings, err := j.prs.assigner.teamSettings(ctx, teamName)
+	if err != nil {
+		return domain.ReviewSLA{}, err
+	}
+
+	cache[teamName] = settings.ReviewSLA
+	return settings.ReviewSLA, nil
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage/memory"
+)
+
+type recordingNotifier struct {
+	reviews []domain.PendingReview
+}
+
+func (n *recordingNotifier) NotifyReviewOverdue(ctx context.Context, review domain.PendingReview, deadline time.Time) error {
+	n.reviews = append(n.reviews, review)
+	return nil
+}
+
+func setupReviewSLAJob(t *testing.T, sla domain.ReviewSLA) (*memory.MemoryRepository, *ReviewSLAJob, *recordingNotifier) {
+	repo := setupOutOfOfficeRepo(t)
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	settings := domain.DefaultTeamSettings("backend")
+	settings.ReviewSLA = sla
+	require.NoError(t, repo.SaveTeamSettings(ctx, settings))
+
+	prService := NewPRService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+	notifier := &recordingNotifier{}
+	job := NewReviewSLAJob(repo, prService, notifier, time.Minute, mockLogger)
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2"}))
+
+	return repo, job, notifier
+}
+
+func TestReviewSLAJob_Notify(t *testing.T) {
+	repo, job, notifier := setupReviewSLAJob(t, domain.ReviewSLA{FirstResponseHours: 24, Action: domain.SLAActionNotify})
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("does nothing within SLA", func(t *testing.T) {
+		job.now = func() time.Time { return now.Add(23 * time.Hour) }
+		require.NoError(t, job.RunOnce(ctx))
+		assert.Empty(t, notifier.reviews)
+	})
+
+	t.Run("notifies once after SLA", func(t *testing.T) {
+		job.now = func() time.Time { return now.Add(25 * time.Hour) }
+		require.NoError(t, job.RunOnce(ctx))
+		require.NoError(t, job.RunOnce(ctx))
+
+		require.Len(t, notifier.reviews, 1)
+		assert.Equal(t, "u2", notifier.reviews[0].ReviewerID)
+
+		reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, reviewers)
+
+		events, err := repo.GetPREvents(ctx, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.PREventSLAEscalated, events[0].Type)
+		assert.Equal(t, "notify", events[0].Reason)
+	})
+
+	t.Run("restarts SLA on reopen", func(t *testing.T) {
+		_, err := job.prs.ClosePR(ctx, domain.ClosePRRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+		_, err = job.prs.ReopenPR(ctx, domain.ReopenPRRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+
+		states, err := repo.GetPRReviewerStates(ctx, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.Nil(t, states[0].EscalatedAt)
+		reopenedAt := states[0].AssignedAt
+		assert.True(t, reopenedAt.After(now))
+
+		job.now = func() time.Time { return reopenedAt.Add(23 * time.Hour) }
+		require.NoError(t, job.RunOnce(ctx))
+		assert.Len(t, notifier.reviews, 1)
+
+		job.now = func() time.Time { return reopenedAt.Add(25 * time.Hour) }
+		require.NoError(t, job.RunOnce(ctx))
+		assert.Len(t, notifier.reviews, 2)
+	})
+}
+
+func TestReviewSLAJob_Reassign(t *testing.T) {
+	repo, job, notifier := setupReviewSLAJob(t, domain.ReviewSLA{FirstResponseHours: 24, Action: domain.SLAActionReassign})
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-reviewed", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u3"}))
+	require.NoError(t, repo.SubmitReview(ctx, "pr-reviewed", "u3", domain.ReviewStateCommented, now))
+
+	job.now = func() time.Time { return now.Add(25 * time.Hour) }
+	require.NoError(t, job.RunOnce(ctx))
+
+	reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, reviewers)
+	assert.Empty(t, notifier.reviews)
+
+	events, err := repo.GetPREvents(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.PREventReassigned, events[0].Type)
+	assert.Equal(t, domain.PREventReasonSLA, events[0].Reason)
+	assert.Equal(t, domain.PREventSLAEscalated, events[1].Type)
+	assert.Equal(t, "reassign", events[1].Reason)
+	assert.Equal(t, "u2", events[1].ReviewerID)
+	assert.Equal(t, "u3", events[1].NewReviewerID)
+
+	// Замена назначена в момент запуска задачи, а не по часам сервера
+	states, err := repo.GetPRReviewerStates(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, now.Add(25*time.Hour), states[0].AssignedAt)
+
+	// Ревьювер, который уже отреагировал, не эскалируется
+	events, err = repo.GetPREvents(ctx, "pr-reviewed")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	t.Run("falls back to notification without candidates", func(t *testing.T) {
+		job.now = func() time.Time { return now.Add(50 * time.Hour) }
+		require.NoError(t, job.RunOnce(ctx))
+
+		require.Len(t, notifier.reviews, 1)
+		assert.Equal(t, "u3", notifier.reviews[0].ReviewerID)
+
+		reviewers, err := repo.GetPRReviewers(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, reviewers)
+	})
+}
@@ -0,0 +1,657 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// ReviewerAssigner содержит общую логику выбора ревьюверов для PRService и TeamService
+type ReviewerAssigner struct {
+	repo           storage.Repository
+	selectors      *ReviewerSelectors
+	capacityPolicy domain.CapacityPolicy
+	now            func() time.Time
+}
+
+func NewReviewerAssigner(repo storage.Repository, selectors *ReviewerSelectors, capacityPolicy domain.CapacityPolicy) (*ReviewerAssigner, error) {
+	if capacityPolicy == "" {
+		capacityPolicy = domain.CapacityPolicyFewer
+	}
+	if !capacityPolicy.Valid() {
+		return nil, fmt.Errorf("unknown capacity policy: %q", capacityPolicy)
+	}
+
+	return &ReviewerAssigner{
+		repo:           repo,
+		selectors:      selectors,
+		capacityPolicy: capacityPolicy,
+		now:            time.Now,
+	}, nil
+}
+
+// selectReviewers выбирает до n ревьюверов по стратегии команды, пропуская отсутствующих и тех, кто достиг лимита
+func (a *ReviewerAssigner) selectReviewers(ctx context.Context, authorID string, source domain.AssignmentSource, teamName string, candidates []domain.User, n int) ([]domain.User, error) {
+	present, absent, err := a.excludeOutOfOffice(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	available, atCapacity, err := a.splitByCapacity(ctx, present)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(available) == 0 && len(atCapacity) > 0 {
+		switch a.capacityPolicy {
+		case domain.CapacityPolicyReject:
+			return nil, domain.ErrReviewersAtCapacity
+		case domain.CapacityPolicyIgnore:
+			available = atCapacity
+			atCapacity = nil
+		}
+	}
+
+	selected, err := a.selectors.ForTeam(teamName).Select(ctx, authorID, available, n)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.recordSelection(ctx, selectionStep{
+		source:     source,
+		teamName:   teamName,
+		membersOf:  teamName,
+		candidates: candidates,
+		pool:       available,
+		absent:     absent,
+		atCapacity: atCapacity,
+		selected:   selected,
+	}); err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
+
+// selectPreferred сначала выбирает из preferred, оставшиеся места заполняет из candidates
+func (a *ReviewerAssigner) selectPreferred(ctx context.Context, authorID string, teamName string, preferred, candidates []domain.User, n int) ([]domain.User, error) {
+	if len(preferred) == 0 {
+		return a.selectReviewers(ctx, authorID, domain.AssignmentSourceTeam, teamName, candidates, n)
+	}
+
+	present, absent, err := a.excludeOutOfOffice(ctx, preferred)
+	if err != nil {
+		return nil, err
+	}
+
+	available, atCapacity, err := a.splitByCapacity(ctx, present)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := a.selectors.ForTeam(teamName).Select(ctx, authorID, available, n)
+	if err != nil {
+		return nil, err
+	}
+
+	// Владельцы кода могут быть из разных команд, поэтому состав команды не учитываем
+	if err := a.recordSelection(ctx, selectionStep{
+		source:     domain.AssignmentSourceCodeOwner,
+		teamName:   teamName,
+		candidates: preferred,
+		pool:       available,
+		absent:     absent,
+		atCapacity: atCapacity,
+		selected:   selected,
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(selected) >= n {
+		return selected, nil
+	}
+
+	picked := make(map[string]bool, len(selected))
+	for _, u := range selected {
+		picked[u.UserID] = true
+	}
+
+	rest := make([]domain.User, 0, len(candidates))
+	for _, c := range candidates {
+		if !picked[c.UserID] {
+			rest = append(rest, c)
+		}
+	}
+
+	filled, err := a.selectReviewers(ctx, authorID, domain.AssignmentSourceTeam, teamName, rest, n-len(selected))
+	if err == domain.ErrReviewersAtCapacity && len(selected) > 0 {
+		return selected, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(selected, filled...), nil
+}
+
+// recordSelection добавляет шаг выбора в трассу назначения, если она включена.
+// Если задан step.membersOf, причины исключения остальных участников этой команды вычисляются по ее составу.
+func (a *ReviewerAssigner) recordSelection(ctx context.Context, step selectionStep) error {
+	trace := traceFromContext(ctx)
+	if trace == nil || len(step.selected) == 0 {
+		return nil
+	}
+
+	excluded := make(map[string]domain.ExclusionReason)
+	for _, u := range step.absent {
+		excluded[u.UserID] = domain.ExclusionOutOfOffice
+	}
+	for _, u := range step.atCapacity {
+		excluded[u.UserID] = domain.ExclusionAtCapacity
+	}
+
+	if step.membersOf != "" {
+		members, err := a.repo.GetUsersByTeam(ctx, step.membersOf)
+		if err != nil {
+			return err
+		}
+
+		considered := make(map[string]bool, len(step.candidates))
+		for _, c := range step.candidates {
+			considered[c.UserID] = true
+		}
+		for _, m := range members {
+			if !considered[m.UserID] {
+				excluded[m.UserID] = trace.exclusionReason(m)
+			}
+		}
+	}
+
+	var strategy domain.SelectionStrategy
+	if step.source != domain.AssignmentSourceRequested {
+		strategy = a.selectors.StrategyFor(step.teamName)
+	}
+
+	trace.add(step, strategy, excluded, a.now())
+
+	return nil
+}
+
+// recordRequested добавляет в трассу ревьюверов, явно запрошенных автором
+func (a *ReviewerAssigner) recordRequested(ctx context.Context, requested []domain.User) error {
+	return a.recordSelection(ctx, selectionStep{
+		source:     domain.AssignmentSourceRequested,
+		candidates: requested,
+		pool:       requested,
+		selected:   requested,
+	})
+}
+
+// selectForTags выбирает по одному ревьюверу на каждый тег, который еще не покрыт выбранными.
+// candidates — участники команды teamName: владельцы кода из других команд назначаются
+// позже через selectPreferred. Теги, для которых нет подходящих кандидатов, пропускаются.
+func (a *ReviewerAssigner) selectForTags(ctx context.Context, authorID string, teamName string, candidates []domain.User, tags []string, n int) ([]domain.User, error) {
+	selected := make([]domain.User, 0, n)
+	picked := make(map[string]bool)
+
+	for _, tag := range tags {
+		if len(selected) >= n {
+			break
+		}
+		if len(uncoveredTags(selected, []string{tag})) == 0 {
+			continue
+		}
+
+		tagged := make([]domain.User, 0)
+		for _, c := range candidates {
+			if !picked[c.UserID] && hasSkill(c, tag) {
+				tagged = append(tagged, c)
+			}
+		}
+		if len(tagged) == 0 {
+			continue
+		}
+
+		chosen, err := a.selectReviewers(ctx, authorID, domain.AssignmentSourceSkillTag, teamName, tagged, 1)
+		if err == domain.ErrReviewersAtCapacity {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range chosen {
+			picked[u.UserID] = true
+		}
+		selected = append(selected, chosen...)
+	}
+
+	return selected, nil
+}
+
+// selectSenior выбирает одного старшего ревьювера из candidates, а если там никого нет —
+// из резервных команд, затем из родительских команд teamName.
+// Второе значение сообщает, что ревьювер взят из резервной команды.
+func (a *ReviewerAssigner) selectSenior(ctx context.Context, authorID string, settings *domain.TeamSettings, teamName string, candidates []domain.User, exclude map[string]bool) (*domain.User, bool, error) {
+	seniors := make([]domain.User, 0)
+	for _, c := range candidates {
+		if !exclude[c.UserID] && c.Level.IsSenior() {
+			seniors = append(seniors, c)
+		}
+	}
+
+	if len(seniors) > 0 {
+		selected, err := a.selectReviewers(ctx, authorID, domain.AssignmentSourceSenior, teamName, seniors, 1)
+		if err != nil && err != domain.ErrReviewersAtCapacity {
+			return nil, false, err
+		}
+		if len(selected) > 0 {
+			return &selected[0], false, nil
+		}
+	}
+
+	fallback, err := a.selectFromFallback(ctx, authorID, settings, exclude, 1, isSenior)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(fallback) > 0 {
+		return &fallback[0], true, nil
+	}
+
+	escalated, err := a.selectFromAncestors(ctx, authorID, teamName, exclude, 1, isSenior)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(escalated) > 0 {
+		return &escalated[0], false, nil
+	}
+
+	return nil, false, domain.ErrSeniorReviewerRequired
+}
+
+// seniorReplacementCandidates возвращает активных участников команды, из которой ищется замена,
+// а если команда PR другая — и участников команды PR
+func (a *ReviewerAssigner) seniorReplacementCandidates(ctx context.Context, replacementTeam, reviewerID, prTeam, authorID string) ([]domain.User, error) {
+	candidates, err := a.repo.GetActiveTeamMembers(ctx, replacementTeam, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if prTeam != replacementTeam {
+		members, err := a.repo.GetActiveTeamMembers(ctx, prTeam, authorID)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, members...)
+	}
+
+	return candidates, nil
+}
+
+// resolveTeam выбирает команду, из которой назначаются ревьюверы нового PR:
+// указанную в запросе, если автор в ней состоит, иначе основную команду автора
+func (a *ReviewerAssigner) resolveTeam(ctx context.Context, author *domain.User, hint string) (string, error) {
+	if hint == "" || hint == author.TeamName {
+		return author.TeamName, nil
+	}
+
+	teams, err := a.repo.GetUserTeams(ctx, author.UserID)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(teams, hint) {
+		return "", domain.ErrAuthorNotInTeam
+	}
+
+	return hint, nil
+}
+
+// prTeam возвращает команду PR; у PR, созданных до выбора команды, это основная команда автора
+func (a *ReviewerAssigner) prTeam(ctx context.Context, pr *domain.PullRequest) (string, error) {
+	if pr.TeamName != "" {
+		return pr.TeamName, nil
+	}
+
+	author, err := a.repo.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return "", err
+	}
+
+	return author.TeamName, nil
+}
+
+// replacementTeam возвращает команду, в которой ищется замена ревьюверу:
+// команду PR, если ревьювер в ней состоит, иначе его основную команду
+func (a *ReviewerAssigner) replacementTeam(ctx context.Context, prTeam string, reviewer *domain.User) (string, error) {
+	if prTeam == reviewer.TeamName {
+		return prTeam, nil
+	}
+
+	teams, err := a.repo.GetUserTeams(ctx, reviewer.UserID)
+	if err != nil {
+		return "", err
+	}
+	if slices.Contains(teams, prTeam) {
+		return prTeam, nil
+	}
+
+	return reviewer.TeamName, nil
+}
+
+// hasSeniorReviewer проверяет, есть ли среди ревьюверов старший
+func (a *ReviewerAssigner) hasSeniorReviewer(ctx context.Context, reviewerIDs []string) (bool, error) {
+	for _, userID := range reviewerIDs {
+		user, err := a.repo.GetUser(ctx, userID)
+		if err == domain.ErrUserNotFound {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if user.Level.IsSenior() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func isSenior(u domain.User) bool {
+	return u.Level.IsSenior()
+}
+
+func hasSenior(users []domain.User) bool {
+	for _, u := range users {
+		if isSenior(u) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectFromFallback добирает до n ревьюверов из резервных команд в заданном порядке.
+// exclude содержит автора и уже назначенных ревьюверов, eligible (если задан) дополнительно отбирает кандидатов.
+func (a *ReviewerAssigner) selectFromFallback(ctx context.Context, authorID string, settings *domain.TeamSettings, exclude map[string]bool, n int, eligible func(domain.User) bool) ([]domain.User, error) {
+	selected := make([]domain.User, 0, n)
+
+	for _, teamName := range settings.FallbackTeams {
+		if len(selected) >= n {
+			break
+		}
+
+		members, err := a.repo.GetActiveTeamMembers(ctx, teamName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]domain.User, 0, len(members))
+		for _, m := range members {
+			if !exclude[m.UserID] && (eligible == nil || eligible(m)) {
+				candidates = append(candidates, m)
+			}
+		}
+
+		picked, err := a.selectReviewers(ctx, authorID, domain.AssignmentSourceFallback, teamName, candidates, n-len(selected))
+		if err == domain.ErrReviewersAtCapacity {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range picked {
+			exclude[u.UserID] = true
+		}
+		selected = append(selected, picked...)
+	}
+
+	return selected, nil
+}
+
+// selectFromAncestors добирает до n ревьюверов, поднимаясь по иерархии от teamName к корню:
+// на каждом уровне кандидатами становятся активные участники родительской команды и ее прямых
+// дочерних команд, кроме ветки, из которой поднялись. Более глубокие команды не просматриваются,
+// чтобы у корня выбор не шел по всей организации. exclude и eligible работают так же, как в selectFromFallback.
+func (a *ReviewerAssigner) selectFromAncestors(ctx context.Context, authorID string, teamName string, exclude map[string]bool, n int, eligible func(domain.User) bool) ([]domain.User, error) {
+	selected := make([]domain.User, 0, n)
+	if n <= 0 || teamName == "" {
+		return selected, nil
+	}
+
+	hierarchy, err := loadTeamHierarchy(ctx, a.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := teamName
+	for _, ancestor := range hierarchy.ancestors(teamName) {
+		if len(selected) >= n {
+			break
+		}
+
+		teams := []string{ancestor}
+		for _, child := range hierarchy.children[ancestor] {
+			if child != previous {
+				teams = append(teams, child)
+			}
+		}
+		previous = ancestor
+
+		members, err := subtreeMembers(ctx, a.repo, teams, true)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]domain.User, 0, len(members))
+		for _, m := range members {
+			if !exclude[m.UserID] && (eligible == nil || eligible(m)) {
+				candidates = append(candidates, m)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		picked, err := a.selectReviewers(ctx, authorID, domain.AssignmentSourceParentTeam, ancestor, candidates, n-len(selected))
+		if err == domain.ErrReviewersAtCapacity {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range picked {
+			exclude[u.UserID] = true
+		}
+		selected = append(selected, picked...)
+	}
+
+	return selected, nil
+}
+
+// codeOwnerCandidates возвращает активных владельцев измененных файлов, кроме автора.
+// Правила репозитория имеют приоритет, для остальных файлов применяются правила команды.
+func (a *ReviewerAssigner) codeOwnerCandidates(ctx context.Context, repository, teamName, authorID string, files []string) ([]domain.User, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	owners := make(map[string][]string, len(files))
+	if repository != "" {
+		repoRules, err := a.repo.GetCodeOwnerRules(ctx, domain.CodeOwnerScopeRepository, repository)
+		if err != nil {
+			return nil, err
+		}
+		owners = matchCodeOwners(repoRules, files)
+	}
+
+	unmatched := make([]string, 0, len(files))
+	for _, file := range files {
+		if _, ok := owners[file]; !ok {
+			unmatched = append(unmatched, file)
+		}
+	}
+
+	if len(unmatched) > 0 {
+		teamRules, err := a.repo.GetCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, teamName)
+		if err != nil {
+			return nil, err
+		}
+		for file, fileOwners := range matchCodeOwners(teamRules, unmatched) {
+			owners[file] = fileOwners
+		}
+	}
+
+	seen := make(map[string]bool)
+	users := make([]domain.User, 0)
+	for _, file := range files {
+		for _, ownerID := range owners[file] {
+			if seen[ownerID] || ownerID == authorID {
+				continue
+			}
+			seen[ownerID] = true
+
+			user, err := a.repo.GetUser(ctx, ownerID)
+			if err == domain.ErrUserNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if user.IsActive {
+				users = append(users, *user)
+			}
+		}
+	}
+
+	return users, nil
+}
+
+// excludeOutOfOffice отделяет кандидатов, которые отсутствуют в момент назначения
+func (a *ReviewerAssigner) excludeOutOfOffice(ctx context.Context, candidates []domain.User) ([]domain.User, []domain.User, error) {
+	if len(candidates) == 0 {
+		return candidates, nil, nil
+	}
+
+	userIDs := make([]string, len(candidates))
+	for i, c := range candidates {
+		userIDs[i] = c.UserID
+	}
+
+	absentIDs, err := a.repo.GetOutOfOfficeUserIDs(ctx, userIDs, a.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(absentIDs) == 0 {
+		return candidates, nil, nil
+	}
+
+	present := make([]domain.User, 0, len(candidates))
+	absent := make([]domain.User, 0, len(absentIDs))
+	for _, c := range candidates {
+		if absentIDs[c.UserID] {
+			absent = append(absent, c)
+			continue
+		}
+		present = append(present, c)
+	}
+
+	return present, absent, nil
+}
+
+// splitByCapacity разделяет кандидатов на тех, у кого есть запас, и тех, кто достиг лимита
+func (a *ReviewerAssigner) splitByCapacity(ctx context.Context, candidates []domain.User) ([]domain.User, []domain.User, error) {
+	limits := make(map[string]int, len(candidates))
+	teamDefaults := make(map[string]*int)
+	limitedIDs := make([]string, 0, len(candidates))
+
+	for _, c := range candidates {
+		limit := c.MaxOpenReviews
+		// Лимит по умолчанию берется из основной команды пользователя
+		if limit == nil && c.TeamName != "" {
+			teamDefault, ok := teamDefaults[c.TeamName]
+			if !ok {
+				team, err := a.repo.GetTeam(ctx, c.TeamName)
+				if err != nil {
+					return nil, nil, err
+				}
+				teamDefault = team.DefaultMaxOpenReviews
+				teamDefaults[c.TeamName] = teamDefault
+			}
+			limit = teamDefault
+		}
+
+		if limit != nil {
+			limits[c.UserID] = *limit
+			limitedIDs = append(limitedIDs, c.UserID)
+		}
+	}
+
+	if len(limitedIDs) == 0 {
+		return candidates, nil, nil
+	}
+
+	load, err := openReviewLoad(ctx, a.repo, limitedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	available := make([]domain.User, 0, len(candidates))
+	atCapacity := make([]domain.User, 0)
+	for _, c := range candidates {
+		limit, limited := limits[c.UserID]
+		if limited && load[c.UserID] >= limit {
+			atCapacity = append(atCapacity, c)
+			continue
+		}
+		available = append(available, c)
+	}
+
+	return available, atCapacity, nil
+}
+
+// teamSettings возвращает настройки команды или значения по умолчанию, если они не заданы
+func (a *ReviewerAssigner) teamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	settings, err := a.repo.GetTeamSettings(ctx, teamName)
+	if err == domain.ErrTeamSettingsNotFound {
+		return domain.DefaultTeamSettings(teamName), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// escalationShortage возвращает, сколько ревьюверов добрать из родительских команд при assigned уже выбранных:
+// до минимума команды, а если минимум не задан — одного, когда не выбран никто
+func escalationShortage(settings *domain.TeamSettings, assigned int) int {
+	need := settings.MinReviewers
+	if need < 1 {
+		need = 1
+	}
+	if need > settings.MaxReviewers {
+		need = settings.MaxReviewers
+	}
+	if assigned >= need {
+		return 0
+	}
+	return need - assigned
+}
+
+// replacementSlots возвращает, скольких из removed снятых ревьюверов можно заменить,
+// не превышая максимум команды при remaining оставшихся
+func replacementSlots(settings *domain.TeamSettings, remaining, removed int) int {
+	slots := settings.MaxReviewers - remaining
+	if slots > removed {
+		slots = removed
+	}
+	if slots < 0 {
+		slots = 0
+	}
+	return slots
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage/memory"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func setupCapacityRepo(t *testing.T) *memory.MemoryRepository {
+	repo := memory.NewMemoryRepository()
+	ctx := context.Background()
+
+	team := &domain.Team{TeamName: "backend", DefaultMaxOpenReviews: intPtr(1)}
+	members := []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true, MaxOpenReviews: intPtr(2)},
+	}
+	require.NoError(t, repo.CreateTeam(ctx, team, members))
+
+	// u2 и u3 уже ревьюят по одному открытому PR
+	pr := &domain.PullRequest{PullRequestID: "pr-0", AuthorID: "u1", Status: domain.PRStatusOpen}
+	require.NoError(t, repo.CreatePR(ctx, pr, []string{"u2", "u3"}))
+
+	return repo
+}
+
+func TestReviewerAssigner_SkipsReviewersAtCapacity(t *testing.T) {
+	repo := setupCapacityRepo(t)
+	ctx := context.Background()
+	assigner := newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer)
+
+	candidates, err := repo.GetActiveTeamMembers(ctx, "backend", "u1")
+	require.NoError(t, err)
+
+	selected, err := assigner.selectReviewers(ctx, "", domain.AssignmentSourceTeam, "backend", candidates, 2)
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+	assert.Equal(t, "u3", selected[0].UserID)
+}
+
+func TestReviewerAssigner_CapacityPolicies(t *testing.T) {
+	ctx := context.Background()
+	candidates := []domain.User{
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}
+
+	t.Run("fewer assigns nobody", func(t *testing.T) {
+		repo := setupCapacityRepo(t)
+		assigner := newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer)
+
+		selected, err := assigner.selectReviewers(ctx, "", domain.AssignmentSourceTeam, "backend", candidates, 2)
+		require.NoError(t, err)
+		assert.Empty(t, selected)
+	})
+
+	t.Run("reject returns AT_CAPACITY", func(t *testing.T) {
+		repo := setupCapacityRepo(t)
+		assigner := newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyReject)
+
+		_, err := assigner.selectReviewers(ctx, "", domain.AssignmentSourceTeam, "backend", candidates, 2)
+		assert.Equal(t, domain.ErrReviewersAtCapacity, err)
+	})
+
+	t.Run("ignore assigns over the limit", func(t *testing.T) {
+		repo := setupCapacityRepo(t)
+		assigner := newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyIgnore)
+
+		selected, err := assigner.selectReviewers(ctx, "", domain.AssignmentSourceTeam, "backend", candidates, 2)
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		assert.Equal(t, "u2", selected[0].UserID)
+	})
+
+	t.Run("rejects unknown policy", func(t *testing.T) {
+		_, err := NewReviewerAssigner(setupCapacityRepo(t), NewDefaultReviewerSelectors(), "skip")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// ReviewerSelector выбирает до n ревьюверов из заранее отфильтрованных кандидатов на PR автора authorID
+type ReviewerSelector interface {
+	Select(ctx context.Context, authorID string, candidates []domain.User, n int) ([]domain.User, error)
+}
+
+func NewReviewerSelector(strategy domain.SelectionStrategy, repo storage.Repository, pairing PairingHistoryOptions) (ReviewerSelector, error) {
+	switch strategy {
+	case domain.SelectionStrategyRandom, "":
+		return NewRandomSelector(time.Now().UnixNano()), nil
+	case domain.SelectionStrategyRoundRobin:
+		return NewRoundRobinSelector(), nil
+	case domain.SelectionStrategyLeastLoaded:
+		return NewLeastLoadedSelector(repo, time.Now().UnixNano()), nil
+	case domain.SelectionStrategyPairingHistory:
+		return NewPairingHistorySelector(repo, pairing, time.Now().UnixNano()), nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy: %q", strategy)
+	}
+}
+
+type RandomSelector struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func NewRandomSelector(seed int64) *RandomSelector {
+	return &RandomSelector{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *RandomSelector) Select(ctx context.Context, authorID string, candidates []domain.User, n int) ([]domain.User, error) {
+	if len(candidates) <= n {
+		return append([]domain.User{}, candidates...), nil
+	}
+
+	s.mu.Lock()
+	perm := s.rand.Perm(len(candidates))
+	s.mu.Unlock()
+
+	selected := make([]domain.User, n)
+	for i := 0; i < n; i++ {
+		selected[i] = candidates[perm[i]]
+	}
+
+	return selected, nil
+}
+
+// RoundRobinSelector выбирает тех, кого дольше всего не назначали.
+// Состояние хранится в памяти процесса и сбрасывается при рестарте.
+type RoundRobinSelector struct {
+	mu       sync.Mutex
+	tick     uint64
+	lastPick map[string]uint64
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		lastPick: make(map[string]uint64),
+	}
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, authorID string, candidates []domain.User, n int) ([]domain.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ordered := append([]domain.User{}, candidates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ti, tj := s.lastPick[ordered[i].UserID], s.lastPick[ordered[j].UserID]
+		if ti != tj {
+			return ti < tj
+		}
+		return ordered[i].UserID < ordered[j].UserID
+	})
+
+	if len(ordered) > n {
+		ordered = ordered[:n]
+	}
+
+	if isDryRun(ctx) {
+		return ordered, nil
+	}
+
+	for _, u := range ordered {
+		s.tick++
+		s.lastPick[u.UserID] = s.tick
+	}
+
+	return ordered, nil
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью.
+// При равной нагрузке порядок определяется случайно.
+type LeastLoadedSelector struct {
+	repo storage.Repository
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func NewLeastLoadedSelector(repo storage.Repository, seed int64) *LeastLoadedSelector {
+	return &LeastLoadedSelector{
+		repo: repo,
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, authorID string, candidates []domain.User, n int) ([]domain.User, error) {
+	userIDs := make([]string, len(candidates))
+	for i, c := range candidates {
+		userIDs[i] = c.UserID
+	}
+
+	load, err := openReviewLoad(ctx, s.repo, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := append([]domain.User{}, candidates...)
+	s.mu.Lock()
+	s.rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+	s.mu.Unlock()
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return load[ordered[i].UserID] < load[ordered[j].UserID]
+	})
+
+	if len(ordered) > n {
+		ordered = ordered[:n]
+	}
+
+	return ordered, nil
+}
+
+// PairingHistoryOptions задает окно истории пар автор→ревьювер и скорость затухания.
+// Пара, случившаяся HalfLife назад, весит вдвое меньше свежей; HalfLife <= 0 отключает затухание.
+type PairingHistoryOptions struct {
+	Lookback time.Duration
+	HalfLife time.Duration
+}
+
+func DefaultPairingHistoryOptions() PairingHistoryOptions {
+	return PairingHistoryOptions{
+		Lookback: 90 * 24 * time.Hour,
+		HalfLife: 14 * 24 * time.Hour,
+	}
+}
+
+// PairingHistorySelector выбирает случайно, понижая вес тех, кто недавно и часто ревьюил автора PR.
+// Вес кандидата — 1 / (1 + score), где score — сумма затухающих весов его пар с автором.
+// История берется из записей о назначениях (assignment_records), которые не меняются
+// при переназначении и снятии ревьюверов; без автора все кандидаты равновероятны.
+type PairingHistorySelector struct {
+	repo    storage.Repository
+	options PairingHistoryOptions
+	now     func() time.Time
+	mu      sync.Mutex
+	rand    *rand.Rand
+}
+
+func NewPairingHistorySelector(repo storage.Repository, options PairingHistoryOptions, seed int64) *PairingHistorySelector {
+	return &PairingHistorySelector{
+		repo:    repo,
+		options: options,
+		now:     time.Now,
+		rand:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *PairingHistorySelector) Select(ctx context.Context, authorID string, candidates []domain.User, n int) ([]domain.User, error) {
+	if len(candidates) <= n {
+		return append([]domain.User{}, candidates...), nil
+	}
+
+	scores, err := s.pairingScores(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := append([]domain.User{}, candidates...)
+	weights := make([]float64, len(pool))
+	for i, c := range pool {
+		weights[i] = 1 / (1 + scores[c.UserID])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	selected := make([]domain.User, 0, n)
+	for len(selected) < n {
+		total := 0.0
+		for _, w := range weights {
+			total += w
+		}
+
+		// Выбор без возвращения: выбранный кандидат убирается из пула
+		r := s.rand.Float64() * total
+		idx := len(pool) - 1
+		for i, w := range weights {
+			if r < w {
+				idx = i
+				break
+			}
+			r -= w
+		}
+
+		selected = append(selected, pool[idx])
+		pool = append(pool[:idx], pool[idx+1:]...)
+		weights = append(weights[:idx], weights[idx+1:]...)
+	}
+
+	return selected, nil
+}
+
+func (s *PairingHistorySelector) pairingScores(ctx context.Context, authorID string) (map[string]float64, error) {
+	scores := make(map[string]float64)
+	if authorID == "" {
+		return scores, nil
+	}
+
+	now := s.now()
+	pairings, err := s.repo.GetReviewPairings(ctx, authorID, now.Add(-s.options.Lookback))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pairings {
+		weight := 1.0
+		if s.options.HalfLife > 0 {
+			age := now.Sub(p.PairedAt)
+			if age < 0 {
+				age = 0
+			}
+			weight = math.Pow(0.5, float64(age)/float64(s.options.HalfLife))
+		}
+		scores[p.ReviewerID] += weight
+	}
+
+	return scores, nil
+}
+
+// ReviewerSelectors хранит стратегию по умолчанию и переопределения для команд
+type ReviewerSelectors struct {
+	defaultStrategy domain.SelectionStrategy
+	defaultSelector ReviewerSelector
+	teamStrategies  map[string]domain.SelectionStrategy
+	teamSelectors   map[string]ReviewerSelector
+}
+
+func NewReviewerSelectors(repo storage.Repository, defaultStrategy domain.SelectionStrategy, teamStrategies map[string]domain.SelectionStrategy, pairing PairingHistoryOptions) (*ReviewerSelectors, error) {
+	defaultSelector, err := NewReviewerSelector(defaultStrategy, repo, pairing)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultStrategy == "" {
+		defaultStrategy = domain.SelectionStrategyRandom
+	}
+
+	strategies := make(map[string]domain.SelectionStrategy, len(teamStrategies))
+	teamSelectors := make(map[string]ReviewerSelector, len(teamStrategies))
+	for teamName, strategy := range teamStrategies {
+		selector, err := NewReviewerSelector(strategy, repo, pairing)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamName, err)
+		}
+		// viper приводит ключи к нижнему регистру, поэтому сравниваем без учета регистра
+		strategies[strings.ToLower(teamName)] = strategy
+		teamSelectors[strings.ToLower(teamName)] = selector
+	}
+
+	return &ReviewerSelectors{
+		defaultStrategy: defaultStrategy,
+		defaultSelector: defaultSelector,
+		teamStrategies:  strategies,
+		teamSelectors:   teamSelectors,
+	}, nil
+}
+
+func NewDefaultReviewerSelectors() *ReviewerSelectors {
+	return &ReviewerSelectors{
+		defaultStrategy: domain.SelectionStrategyRandom,
+		defaultSelector: NewRandomSelector(time.Now().UnixNano()),
+		teamStrategies:  make(map[string]domain.SelectionStrategy),
+		teamSelectors:   make(map[string]ReviewerSelector),
+	}
+}
+
+func (s *ReviewerSelectors) ForTeam(teamName string) ReviewerSelector {
+	if selector, ok := s.teamSelectors[strings.ToLower(teamName)]; ok {
+		return selector
+	}
+	return s.defaultSelector
+}
+
+func (s *ReviewerSelectors) StrategyFor(teamName string) domain.SelectionStrategy {
+	if strategy, ok := s.teamStrategies[strings.ToLower(teamName)]; ok {
+		return strategy
+	}
+	return s.defaultStrategy
+}
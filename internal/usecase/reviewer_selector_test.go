@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage/memory"
+)
+
+func TestRandomSelector_Select(t *testing.T) {
+	selector := NewRandomSelector(42)
+	ctx := context.Background()
+
+	candidates := []domain.User{
+		{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"},
+	}
+
+	t.Run("returns n distinct candidates", func(t *testing.T) {
+		selected, err := selector.Select(ctx, "", candidates, 2)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		assert.NotEqual(t, selected[0].UserID, selected[1].UserID)
+	})
+
+	t.Run("returns all candidates when there are not enough", func(t *testing.T) {
+		selected, err := selector.Select(ctx, "", candidates[:1], 2)
+		require.NoError(t, err)
+		assert.Len(t, selected, 1)
+	})
+}
+
+func TestRoundRobinSelector_Select(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	ctx := context.Background()
+
+	candidates := []domain.User{
+		{UserID: "u3"}, {UserID: "u1"}, {UserID: "u2"},
+	}
+
+	picked := make([]string, 0, 6)
+	for i := 0; i < 6; i++ {
+		selected, err := selector.Select(ctx, "", candidates, 1)
+		require.NoError(t, err)
+		require.Len(t, selected, 1)
+		picked = append(picked, selected[0].UserID)
+	}
+
+	assert.Equal(t, []string{"u1", "u2", "u3", "u1", "u2", "u3"}, picked)
+}
+
+func TestLeastLoadedSelector_Select(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u0", Status: domain.PRStatusOpen}, []string{"u1", "u2"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u0", Status: domain.PRStatusOpen}, []string{"u1"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-3", AuthorID: "u0", Status: domain.PRStatusOpen}, []string{"u3", "u4"}))
+	require.NoError(t, repo.MergePR(ctx, "pr-3"))
+
+	selector := NewLeastLoadedSelector(repo, 42)
+	candidates := []domain.User{
+		{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"},
+	}
+
+	t.Run("prefers reviewers with fewer open reviews", func(t *testing.T) {
+		selected, err := selector.Select(ctx, "", candidates, 2)
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		assert.Equal(t, "u3", selected[0].UserID)
+		assert.Equal(t, "u2", selected[1].UserID)
+	})
+
+	t.Run("breaks ties randomly", func(t *testing.T) {
+		tied := []domain.User{{UserID: "u3"}, {UserID: "u4"}, {UserID: "u5"}}
+		seen := make(map[string]bool)
+		for i := 0; i < 50; i++ {
+			selected, err := selector.Select(ctx, "", tied, 1)
+			require.NoError(t, err)
+			seen[selected[0].UserID] = true
+		}
+		assert.Len(t, seen, 3)
+	})
+
+	t.Run("accounts for pending load", func(t *testing.T) {
+		pendingCtx := withPendingLoad(ctx)
+		addPendingLoad(pendingCtx, "u3", 2)
+
+		selected, err := selector.Select(pendingCtx, "", candidates, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "u2", selected[0].UserID)
+	})
+}
+
+func TestPairingHistorySelector_Select(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	recent := now.Add(-time.Hour)
+	old := now.Add(-200 * 24 * time.Hour)
+	assign := func(prID, authorID, reviewerID string, at time.Time) {
+		require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: prID, AuthorID: authorID, Status: domain.PRStatusOpen}, []string{reviewerID}))
+		require.NoError(t, repo.SaveAssignmentRecords(ctx, []domain.AssignmentRecord{{PullRequestID: prID, ReviewerID: reviewerID, Source: domain.AssignmentSourceTeam, AssignedAt: at}}))
+	}
+	for i := 0; i < 5; i++ {
+		assign(fmt.Sprintf("recent-%d", i), "author", "u1", recent)
+		assign(fmt.Sprintf("old-%d", i), "author", "u2", old)
+		assign(fmt.Sprintf("other-%d", i), "other", "u2", recent)
+	}
+	// Снятие ревьювера не стирает историю пар
+	require.NoError(t, repo.RemoveReviewer(ctx, "recent-0", "u1"))
+
+	selector := NewPairingHistorySelector(repo, DefaultPairingHistoryOptions(), 42)
+	candidates := []domain.User{{UserID: "u1"}, {UserID: "u2"}}
+
+	t.Run("down-weights frequent recent pairs", func(t *testing.T) {
+		picked := make(map[string]int)
+		for i := 0; i < 200; i++ {
+			selected, err := selector.Select(ctx, "author", candidates, 1)
+			require.NoError(t, err)
+			require.Len(t, selected, 1)
+			picked[selected[0].UserID]++
+		}
+
+		// Пары вне окна и пары с другими авторами не учитываются
+		assert.Greater(t, picked["u2"], 3*picked["u1"])
+		assert.Positive(t, picked["u1"])
+	})
+
+	t.Run("pairs decay with age", func(t *testing.T) {
+		scores, err := selector.pairingScores(ctx, "author")
+		require.NoError(t, err)
+		assert.InDelta(t, 5, scores["u1"], 0.1)
+		assert.Zero(t, scores["u2"])
+
+		selector.now = func() time.Time { return now.Add(14 * 24 * time.Hour) }
+		defer func() { selector.now = time.Now }()
+
+		scores, err = selector.pairingScores(ctx, "author")
+		require.NoError(t, err)
+		assert.InDelta(t, 2.5, scores["u1"], 0.1)
+	})
+
+	t.Run("returns n distinct candidates", func(t *testing.T) {
+		many := []domain.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}, {UserID: "u4"}}
+		selected, err := selector.Select(ctx, "author", many, 3)
+		require.NoError(t, err)
+		require.Len(t, selected, 3)
+
+		seen := make(map[string]bool)
+		for _, u := range selected {
+			seen[u.UserID] = true
+		}
+		assert.Len(t, seen, 3)
+	})
+}
+
+func TestReviewerSelectors_ForTeam(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+
+	selectors, err := NewReviewerSelectors(repo, domain.SelectionStrategyRandom, map[string]domain.SelectionStrategy{
+		"backend": domain.SelectionStrategyRoundRobin,
+	}, DefaultPairingHistoryOptions())
+	require.NoError(t, err)
+
+	assert.IsType(t, &RoundRobinSelector{}, selectors.ForTeam("Backend"))
+	assert.IsType(t, &RandomSelector{}, selectors.ForTeam("frontend"))
+
+	_, err = NewReviewerSelectors(repo, "unknown", nil, DefaultPairingHistoryOptions())
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"strings"
+
+	"pr-reviewer/internal/domain"
+)
+
+// normalizeSkills приводит теги к нижнему регистру и убирает дубликаты, сохраняя порядок.
+// Возвращает false, если среди тегов есть пустой.
+func normalizeSkills(skills []string) ([]string, bool) {
+	if skills == nil {
+		return nil, true
+	}
+
+	seen := make(map[string]bool, len(skills))
+	normalized := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" {
+			return nil, false
+		}
+		if seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		normalized = append(normalized, skill)
+	}
+
+	return normalized, true
+}
+
+func hasSkill(user domain.User, skill string) bool {
+	for _, s := range user.Skills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// uncoveredTags возвращает теги, которых нет ни у одного из ревьюверов
+func uncoveredTags(reviewers []domain.User, tags []string) []string {
+	var uncovered []string
+	for _, tag := range tags {
+		covered := false
+		for _, r := range reviewers {
+			if hasSkill(r, tag) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, tag)
+		}
+	}
+	return uncovered
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/infrastructure/storage"
+)
+
+// teamHierarchy — дерево команд по ParentTeam, загруженное одним запросом
+type teamHierarchy struct {
+	parents  map[string]string
+	children map[string][]string
+}
+
+func loadTeamHierarchy(ctx context.Context, repo storage.Repository) (*teamHierarchy, error) {
+	teams, err := repo.ListTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &teamHierarchy{
+		parents:  make(map[string]string, len(teams)),
+		children: make(map[string][]string),
+	}
+	// ListTeams упорядочен по имени, поэтому дочерние команды тоже идут по имени
+	for _, t := range teams {
+		h.parents[t.TeamName] = t.ParentTeam
+		if t.ParentTeam != "" {
+			h.children[t.ParentTeam] = append(h.children[t.ParentTeam], t.TeamName)
+		}
+	}
+
+	return h, nil
+}
+
+// exists сообщает, известна ли команда
+func (h *teamHierarchy) exists(teamName string) bool {
+	_, ok := h.parents[teamName]
+	return ok
+}
+
+// roots возвращает команды без родителя, упорядоченные по имени.
+// Команда, чей родитель не найден, тоже считается корневой.
+func (h *teamHierarchy) roots() []string {
+	result := make([]string, 0)
+	for name, parent := range h.parents {
+		if parent == "" || !h.exists(parent) {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ancestors возвращает родителей команды от ближайшего к корню
+func (h *teamHierarchy) ancestors(teamName string) []string {
+	result := make([]string, 0)
+	seen := map[string]bool{teamName: true}
+	for parent := h.parents[teamName]; parent != "" && !seen[parent]; parent = h.parents[parent] {
+		seen[parent] = true
+		result = append(result, parent)
+	}
+	return result
+}
+
+// subtree возвращает команду и всех ее потомков в порядке обхода в ширину
+func (h *teamHierarchy) subtree(root string) []string {
+	result := []string{root}
+	seen := map[string]bool{root: true}
+	for i := 0; i < len(result); i++ {
+		for _, child := range h.children[result[i]] {
+			if !seen[child] {
+				seen[child] = true
+				result = append(result, child)
+			}
+		}
+	}
+	return result
+}
+
+// depth возвращает число предков команды
+func (h *teamHierarchy) depth(teamName string) int {
+	return len(h.ancestors(teamName))
+}
+
+// subtreeMembers собирает участников всех команд поддерева без повторов.
+// Если active, возвращаются только активные участники.
+func subtreeMembers(ctx context.Context, repo storage.Repository, teams []string, active bool) ([]domain.User, error) {
+	seen := make(map[string]bool)
+	members := make([]domain.User, 0)
+	for _, teamName := range teams {
+		var users []domain.User
+		var err error
+		if active {
+			users, err = repo.GetActiveTeamMembers(ctx, teamName, "")
+		} else {
+			users, err = repo.GetUsersByTeam(ctx, teamName)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range users {
+			if !seen[u.UserID] {
+				seen[u.UserID] = true
+				members = append(members, u)
+			}
+		}
+	}
+
+	return members, nil
+}
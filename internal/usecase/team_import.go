@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"pr-reviewer/internal/domain"
+)
+
+// importRow — строка импорта: участник команды или, при пустом userID, сама команда.
+// isActive равен nil, если is_active не указан. problem заполняется, если строку
+// не удалось разобрать, и делает ее отклоненной.
+type importRow struct {
+	line       int
+	teamName   string
+	parentTeam string
+	userID     string
+	username   string
+	isActive   *bool
+	problem    string
+}
+
+func parseImport(format domain.ImportFormat, content string) ([]importRow, error) {
+	var rows []importRow
+	var err error
+
+	switch format {
+	case domain.ImportFormatCSV:
+		rows, err = parseImportCSV(content)
+	case domain.ImportFormatYAML:
+		rows, err = parseImportYAML(content)
+	default:
+		return nil, domain.ErrInvalidImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, domain.ErrEmptyImport
+	}
+
+	return rows, nil
+}
+
+var importColumns = map[string]bool{
+	"team_name":   true,
+	"parent_team": true,
+	"user_id":     true,
+	"username":    true,
+	"is_active":   true,
+}
+
+// parseImportCSV разбирает CSV с заголовком. Обязательна колонка team_name, остальные
+// (parent_team, user_id, username, is_active) необязательны; пустой is_active оставляет
+// активность существующего пользователя, новый создается активным.
+func parseImportCSV(content string) ([]importRow, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, domain.ErrEmptyImport
+	}
+	if err != nil {
+		return nil, importParseError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !importColumns[name] {
+			return nil, domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("line 1: unknown column %q", name))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["team_name"]; !ok {
+		return nil, domain.NewAppError(domain.ErrCodeBadRequest, "line 1: column team_name is required")
+	}
+
+	rows := make([]importRow, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, importParseError(err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importRow{
+			line:       line,
+			teamName:   field("team_name"),
+			parentTeam: field("parent_team"),
+			userID:     field("user_id"),
+			username:   field("username"),
+		}
+		if raw := field("is_active"); raw != "" {
+			if isActive, err := strconv.ParseBool(raw); err != nil {
+				row.problem = "is_active must be true or false"
+			} else {
+				row.isActive = &isActive
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func importParseError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("line %d: %v", parseErr.Line, parseErr.Err))
+	}
+	return domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("failed to read import: %v", err))
+}
+
+type importTeamYAML struct {
+	TeamName   string      `yaml:"team_name"`
+	ParentTeam string      `yaml:"parent_team"`
+	Members    []yaml.Node `yaml:"members"`
+}
+
+type importMemberYAML struct {
+	UserID   string `yaml:"user_id"`
+	Username string `yaml:"username"`
+	IsActive *bool  `yaml:"is_active"`
+}
+
+// parseImportYAML разбирает документ вида {teams: [{team_name, parent_team, members: [...]}]}.
+// Каждая команда и каждый участник становятся отдельной строкой с номером строки в файле.
+func parseImportYAML(content string) ([]importRow, error) {
+	var doc struct {
+		Teams []yaml.Node `yaml:"teams"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("invalid yaml: %v", err))
+	}
+
+	rows := make([]importRow, 0)
+	for i := range doc.Teams {
+		var team importTeamYAML
+		if err := doc.Teams[i].Decode(&team); err != nil {
+			return nil, domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("line %d: %v", doc.Teams[i].Line, err))
+		}
+
+		rows = append(rows, importRow{
+			line:       doc.Teams[i].Line,
+			teamName:   strings.TrimSpace(team.TeamName),
+			parentTeam: strings.TrimSpace(team.ParentTeam),
+		})
+
+		for j := range team.Members {
+			var member importMemberYAML
+			if err := team.Members[j].Decode(&member); err != nil {
+				return nil, domain.NewAppError(domain.ErrCodeBadRequest, fmt.Sprintf("line %d: %v", team.Members[j].Line, err))
+			}
+
+			row := importRow{
+				line:     team.Members[j].Line,
+				teamName: strings.TrimSpace(team.TeamName),
+				userID:   strings.TrimSpace(member.UserID),
+				username: strings.TrimSpace(member.Username),
+				isActive: member.IsActive,
+			}
+			// Участник без user_id иначе был бы принят за объявление команды
+			if row.userID == "" {
+				row.problem = "user_id is required"
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// importPlan накапливает изменения, которые внесут принятые строки импорта.
+// Строки проверяются по порядку с учетом уже принятых, поэтому родительская команда
+// должна существовать или быть объявлена выше.
+type importPlan struct {
+	parents       map[string]string
+	newTeams      []domain.Team
+	parentChanges map[string]string
+	users         map[string]*domain.User
+	userOrder     []string
+	deactivated   []string
+	memberships   map[string]map[string]bool
+	newMembers    []domain.TeamMembership
+}
+
+func newImportPlan(hierarchy *teamHierarchy) *importPlan {
+	parents := make(map[string]string, len(hierarchy.parents))
+	for team, parent := range hierarchy.parents {
+		parents[team] = parent
+	}
+
+	return &importPlan{
+		parents:       parents,
+		parentChanges: make(map[string]string),
+		users:         make(map[string]*domain.User),
+		memberships:   make(map[string]map[string]bool),
+	}
+}
+
+// createsCycle сообщает, окажется ли team своим же предком при родителе parent
+func (p *importPlan) createsCycle(team, parent string) bool {
+	seen := make(map[string]bool)
+	for current := parent; current != "" && !seen[current]; current = p.parents[current] {
+		if current == team {
+			return true
+		}
+		seen[current] = true
+	}
+	return false
+}
+
+// planTeam проверяет команду строки и возвращает действие над ней, не меняя план
+func (p *importPlan) planTeam(row importRow) (domain.ImportAction, string) {
+	if row.teamName == "" {
+		return domain.ImportActionRejected, "team_name is required"
+	}
+
+	currentParent, exists := p.parents[row.teamName]
+	if row.parentTeam == "" || row.parentTeam == currentParent {
+		if exists {
+			return domain.ImportActionUnchanged, ""
+		}
+		return domain.ImportActionCreated, ""
+	}
+
+	if _, ok := p.parents[row.parentTeam]; !ok || row.parentTeam == row.teamName {
+		return domain.ImportActionRejected, "parent team must exist or be declared above"
+	}
+	if p.createsCycle(row.teamName, row.parentTeam) {
+		return domain.ImportActionRejected, "parent team must not be the team's descendant"
+	}
+	if _, changed := p.parentChanges[row.teamName]; changed || p.declaredInImport(row.teamName) {
+		return domain.ImportActionRejected, "conflicting parent_team for team"
+	}
+
+	if exists {
+		return domain.ImportActionUpdated, ""
+	}
+	return domain.ImportActionCreated, ""
+}
+
+// declaredInImport сообщает, создана ли команда одной из предыдущих строк
+func (p *importPlan) declaredInImport(teamName string) bool {
+	for _, team := range p.newTeams {
+		if team.TeamName == teamName {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMember вносит в план участника уже принятой строки.
+// deactivating означает, что строка деактивирует активного сейчас пользователя.
+func (p *importPlan) applyMember(row importRow, user *domain.User, deactivating bool) {
+	if _, planned := p.users[user.UserID]; !planned {
+		p.users[user.UserID] = user
+		p.userOrder = append(p.userOrder, user.UserID)
+		if deactivating {
+			p.deactivated = append(p.deactivated, user.UserID)
+		}
+	}
+
+	if p.memberships[row.teamName] == nil {
+		p.memberships[row.teamName] = make(map[string]bool)
+	}
+	p.memberships[row.teamName][user.UserID] = true
+	p.newMembers = append(p.newMembers, domain.TeamMembership{TeamName: row.teamName, UserID: user.UserID})
+}
+
+// applyTeam вносит в план команду уже принятой строки
+func (p *importPlan) applyTeam(row importRow, action domain.ImportAction) {
+	switch action {
+	case domain.ImportActionCreated:
+		p.parents[row.teamName] = row.parentTeam
+		p.newTeams = append(p.newTeams, domain.Team{TeamName: row.teamName, ParentTeam: row.parentTeam})
+	case domain.ImportActionUpdated:
+		p.parents[row.teamName] = row.parentTeam
+		p.parentChanges[row.teamName] = row.parentTeam
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pr-reviewer/internal/domain"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	content := `team_name, user_id, username, is_active, parent_team
+backend,u1,Alice,true,engineering
+backend,u2,Bob,,
+backend,u3,Carol,maybe,
+`
+
+	rows, err := parseImportCSV(content)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	active := true
+	assert.Equal(t, importRow{line: 2, teamName: "backend", parentTeam: "engineering", userID: "u1", username: "Alice", isActive: &active}, rows[0])
+	assert.Nil(t, rows[1].isActive)
+	assert.Equal(t, 4, rows[2].line)
+	assert.NotEmpty(t, rows[2].problem)
+
+	_, err = parseImportCSV("team_name,email\nbackend,a@example.com\n")
+	assert.Error(t, err)
+	_, err = parseImportCSV("user_id,username\nu1,Alice\n")
+	assert.Error(t, err)
+	_, err = parseImportCSV("team_name,user_id\nbackend\n")
+	assert.Error(t, err)
+}
+
+func TestParseImportYAML(t *testing.T) {
+	content := `teams:
+  - team_name: engineering
+  - team_name: backend
+    parent_team: engineering
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+        is_active: false
+      - username: Nobody
+`
+
+	rows, err := parseImportYAML(content)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	assert.Equal(t, importRow{line: 2, teamName: "engineering"}, rows[0])
+	assert.Equal(t, importRow{line: 3, teamName: "backend", parentTeam: "engineering"}, rows[1])
+	assert.Equal(t, importRow{line: 6, teamName: "backend", userID: "u1", username: "Alice"}, rows[2])
+	require.NotNil(t, rows[3].isActive)
+	assert.False(t, *rows[3].isActive)
+	assert.Equal(t, "user_id is required", rows[4].problem)
+
+	_, err = parseImportYAML("teams: [{team_name: backend, members: [{user_id: u1, is_active: maybe}]}]")
+	assert.Error(t, err)
+}
+
+func TestParseImport(t *testing.T) {
+	_, err := parseImport("json", `{}`)
+	assert.Equal(t, domain.ErrInvalidImportFormat, err)
+
+	_, err = parseImport(domain.ImportFormatCSV, "team_name,user_id\n")
+	assert.Equal(t, domain.ErrEmptyImport, err)
+
+	_, err = parseImport(domain.ImportFormatYAML, "teams: []")
+	assert.Equal(t, domain.ErrEmptyImport, err)
+}
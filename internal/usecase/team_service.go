@@ -2,97 +2,1106 @@ package usecase
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
+
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/infrastructure/logger"
 	"pr-reviewer/internal/infrastructure/storage"
 )
 
-type TeamService struct {
-	repo   storage.Repository
-	tx     domain.TransactionManager
-	logger logger.Logger
+type TeamService struct {
+	repo     storage.Repository
+	tx       domain.TransactionManager
+	assigner *ReviewerAssigner
+	logger   logger.Logger
+}
+
+func NewTeamService(repo storage.Repository, tx domain.TransactionManager, assigner *ReviewerAssigner, logger logger.Logger) *TeamService {
+	return &TeamService{
+		repo:     repo,
+		tx:       tx,
+		assigner: assigner,
+		logger:   logger,
+	}
+}
+
+func (s *TeamService) CreateTeam(ctx context.Context, req domain.CreateTeamRequest) (*domain.TeamResponse, error) {
+	var result *domain.TeamResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		exists, err := s.repo.TeamExists(ctx, req.TeamName)
+		if err != nil {
+			s.logger.Error("Failed to check team existence", "error", err)
+			return err
+		}
+		if exists {
+			return domain.ErrTeamAlreadyExists
+		}
+
+		if isNegative(req.DefaultMaxOpenReviews) {
+			return domain.ErrInvalidCapacity
+		}
+
+		if req.ParentTeam != "" {
+			if _, err := s.repo.GetTeam(ctx, req.ParentTeam); err != nil {
+				if err == domain.ErrTeamNotFound {
+					return domain.ErrInvalidParentTeam
+				}
+				s.logger.Error("Failed to get parent team", "error", err)
+				return err
+			}
+		}
+
+		team := &domain.Team{
+			TeamName:              req.TeamName,
+			DefaultMaxOpenReviews: req.DefaultMaxOpenReviews,
+			ParentTeam:            req.ParentTeam,
+		}
+
+		members := make([]domain.User, len(req.Members))
+		deactivated := make([]string, 0)
+		for i, m := range req.Members {
+			var deactivating bool
+			members[i], deactivating, err = s.teamMemberUser(ctx, req.TeamName, m)
+			if err != nil {
+				return err
+			}
+			if deactivating {
+				deactivated = append(deactivated, m.UserID)
+			}
+		}
+
+		if err := s.repo.CreateTeam(ctx, team, members); err != nil {
+			s.logger.Error("Failed to create team", "error", err)
+			return err
+		}
+
+		reassigned, err := s.reassignDeactivated(ctx, deactivated)
+		if err != nil {
+			return err
+		}
+
+		responseMembers := make([]domain.TeamMember, len(members))
+		for i, m := range members {
+			responseMembers[i] = domain.TeamMember{
+				UserID:         m.UserID,
+				Username:       m.Username,
+				IsActive:       m.IsActive,
+				MaxOpenReviews: m.MaxOpenReviews,
+				Skills:         m.Skills,
+				Level:          m.Level,
+			}
+		}
+
+		result = &domain.TeamResponse{
+			TeamName:              req.TeamName,
+			DefaultMaxOpenReviews: req.DefaultMaxOpenReviews,
+			ParentTeam:            req.ParentTeam,
+			Members:               responseMembers,
+			ReassignedPRs:         reassigned,
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// memberUser проверяет участника команды из запроса и собирает по нему пользователя
+func memberUser(teamName string, m domain.TeamMember) (domain.User, error) {
+	if isNegative(m.MaxOpenReviews) {
+		return domain.User{}, domain.ErrInvalidCapacity
+	}
+	skills, ok := normalizeSkills(m.Skills)
+	if !ok {
+		return domain.User{}, domain.ErrInvalidSkill
+	}
+	if !m.Level.Valid() {
+		return domain.User{}, domain.ErrInvalidUserLevel
+	}
+
+	return domain.User{
+		UserID:         m.UserID,
+		Username:       m.Username,
+		TeamName:       teamName,
+		IsActive:       m.IsActive,
+		MaxOpenReviews: m.MaxOpenReviews,
+		Skills:         skills,
+		Level:          m.Level,
+	}, nil
+}
+
+// teamMemberUser собирает пользователя, вступающего в teamName. Новый создается из запроса
+// с основной командой teamName. У существующего меняются только переданные поля (username,
+// max_open_reviews, skills, level) и is_active, основная команда сохраняется. deactivating
+// сообщает, что активный сейчас пользователь деактивируется и его ревью нужно переназначить.
+func (s *TeamService) teamMemberUser(ctx context.Context, teamName string, m domain.TeamMember) (domain.User, bool, error) {
+	user, err := memberUser(teamName, m)
+	if err != nil {
+		return domain.User{}, false, err
+	}
+
+	existing, err := s.repo.GetUser(ctx, m.UserID)
+	if err == domain.ErrUserNotFound {
+		return user, false, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get user", "error", err)
+		return domain.User{}, false, err
+	}
+
+	deactivating := existing.IsActive && !m.IsActive
+	existing.IsActive = m.IsActive
+	if m.Username != "" {
+		existing.Username = m.Username
+	}
+	if m.MaxOpenReviews != nil {
+		existing.MaxOpenReviews = user.MaxOpenReviews
+	}
+	if m.Skills != nil {
+		existing.Skills = user.Skills
+	}
+	if m.Level != "" {
+		existing.Level = user.Level
+	}
+	if existing.TeamName == "" {
+		existing.TeamName = teamName
+	}
+
+	return *existing, deactivating, nil
+}
+
+// reassignDeactivated снимает деактивированных пользователей с открытых ревью так же,
+// как в /team/deactivateUsers; вызывается внутри транзакции после сохранения пользователей
+func (s *TeamService) reassignDeactivated(ctx context.Context, userIDs []string) ([]domain.PRReassignmentSummary, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	return s.reassignReviews(ctx, userIDs, "", domain.PREventReasonDeactivation)
+}
+
+// AddMembers добавляет пользователей в существующую команду: новые создаются, у существующих
+// обновляются переданные поля (см. teamMemberUser) и добавляется членство; ревью деактивированных
+// переназначаются. Пользователь может
+// состоять в нескольких командах, его основная команда при этом не меняется.
+func (s *TeamService) AddMembers(ctx context.Context, req domain.AddTeamMembersRequest) (*domain.TeamResponse, error) {
+	var result *domain.TeamResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetTeam(ctx, req.TeamName); err != nil {
+			s.logger.Error("Failed to get team", "error", err)
+			return err
+		}
+
+		deactivated := make([]string, 0)
+		for _, m := range req.Members {
+			user, deactivating, err := s.teamMemberUser(ctx, req.TeamName, m)
+			if err != nil {
+				return err
+			}
+			if deactivating {
+				deactivated = append(deactivated, user.UserID)
+			}
+
+			if err := s.repo.CreateOrUpdateUser(ctx, &user); err != nil {
+				s.logger.Error("Failed to save user", "error", err)
+				return err
+			}
+			if err := s.repo.AddTeamMember(ctx, req.TeamName, user.UserID); err != nil {
+				s.logger.Error("Failed to add team member", "error", err)
+				return err
+			}
+		}
+
+		reassigned, err := s.reassignDeactivated(ctx, deactivated)
+		if err != nil {
+			return err
+		}
+
+		result, err = s.GetTeam(ctx, req.TeamName)
+		if err != nil {
+			return err
+		}
+		result.ReassignedPRs = reassigned
+
+		return nil
+	})
+
+	return result, err
+}
+
+// RemoveMembers убирает пользователей из команды. Ревью на PR этой команды переназначаются так же,
+// как при деактивации; у тех, кто больше ни в одной команде не состоит, переназначаются все открытые ревью.
+// Если команда была основной, основной становится первая из оставшихся.
+func (s *TeamService) RemoveMembers(ctx context.Context, req domain.RemoveTeamMembersRequest) (*domain.RemoveTeamMembersResponse, error) {
+	var result *domain.RemoveTeamMembersResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetTeam(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		userIDs := s.filterValidTeamUsers(ctx, req.TeamName, req.UserIDs)
+		if len(userIDs) == 0 {
+			return domain.ErrNoMembersToRemove
+		}
+
+		summaries, err := s.reassignReviews(ctx, userIDs, req.TeamName, domain.PREventReasonTeamChange)
+		if err != nil {
+			return err
+		}
+
+		// Ревью на PR других команд остаются у тех, кто еще где-то состоит
+		leaving := make([]string, 0, len(userIDs))
+		for _, userID := range userIDs {
+			teams, err := s.repo.GetUserTeams(ctx, userID)
+			if err != nil {
+				s.logger.Error("Failed to get user teams", "user_id", userID, "error", err)
+				return err
+			}
+			if len(teams) == 1 {
+				leaving = append(leaving, userID)
+			}
+		}
+		if len(leaving) > 0 {
+			rest, err := s.reassignReviews(ctx, leaving, "", domain.PREventReasonTeamChange)
+			if err != nil {
+				return err
+			}
+			summaries = append(summaries, rest...)
+		}
+
+		for _, userID := range userIDs {
+			if err := s.leaveTeam(ctx, userID, req.TeamName); err != nil {
+				return err
+			}
+		}
+
+		result = &domain.RemoveTeamMembersResponse{
+			TeamName:      req.TeamName,
+			RemovedUsers:  userIDs,
+			ReassignedPRs: summaries,
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// MoveUser переводит пользователя из команды from_team (по умолчанию основной) в другую команду.
+// Ревью на PR прежней команды переназначаются внутри нее до перевода, членство в остальных командах
+// сохраняется. Если прежняя команда была основной, основной становится новая.
+// Перевод в ту же команду ничего не меняет.
+func (s *TeamService) MoveUser(ctx context.Context, req domain.MoveUserTeamRequest) (*domain.MoveUserTeamResponse, error) {
+	var result *domain.MoveUserTeamResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.repo.GetUser(ctx, req.UserID)
+		if err != nil {
+			s.logger.Error("Failed to get user", "error", err)
+			return err
+		}
+
+		if _, err := s.repo.GetTeam(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		fromTeam := req.FromTeam
+		if fromTeam == "" {
+			fromTeam = user.TeamName
+		}
+
+		result = &domain.MoveUserTeamResponse{
+			UserID:        user.UserID,
+			FromTeam:      fromTeam,
+			TeamName:      req.TeamName,
+			ReassignedPRs: []domain.PRReassignmentSummary{},
+		}
+
+		if fromTeam == req.TeamName {
+			return nil
+		}
+
+		if fromTeam != "" {
+			teams, err := s.repo.GetUserTeams(ctx, user.UserID)
+			if err != nil {
+				s.logger.Error("Failed to get user teams", "error", err)
+				return err
+			}
+			if !slices.Contains(teams, fromTeam) {
+				return domain.ErrUserNotInTeam
+			}
+
+			result.ReassignedPRs, err = s.reassignReviews(ctx, []string{user.UserID}, fromTeam, domain.PREventReasonTeamChange)
+			if err != nil {
+				return err
+			}
+
+			if err := s.repo.RemoveTeamMember(ctx, fromTeam, user.UserID); err != nil {
+				s.logger.Error("Failed to remove team member", "error", err)
+				return err
+			}
+		}
+
+		if err := s.repo.AddTeamMember(ctx, req.TeamName, user.UserID); err != nil {
+			s.logger.Error("Failed to add team member", "error", err)
+			return err
+		}
+
+		if user.TeamName == fromTeam {
+			if err := s.repo.SetUserTeam(ctx, user.UserID, req.TeamName); err != nil {
+				s.logger.Error("Failed to move user", "error", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// leaveTeam исключает пользователя из команды и, если она была основной, назначает основной
+// первую из оставшихся; вызывается внутри транзакции
+func (s *TeamService) leaveTeam(ctx context.Context, userID, teamName string) error {
+	if err := s.repo.RemoveTeamMember(ctx, teamName, userID); err != nil {
+		s.logger.Error("Failed to remove team member", "user_id", userID, "error", err)
+		return err
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", "user_id", userID, "error", err)
+		return err
+	}
+	if user.TeamName != teamName {
+		return nil
+	}
+
+	remaining, err := s.repo.GetUserTeams(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user teams", "user_id", userID, "error", err)
+		return err
+	}
+
+	primary := ""
+	if len(remaining) > 0 {
+		primary = remaining[0]
+	}
+	if err := s.repo.SetUserTeam(ctx, userID, primary); err != nil {
+		s.logger.Error("Failed to set primary team", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.TeamResponse, error) {
+	team, err := s.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		s.logger.Error("Failed to get team", "error", err)
+		return nil, err
+	}
+
+	return &domain.TeamResponse{
+		TeamName:              team.TeamName,
+		DefaultMaxOpenReviews: team.DefaultMaxOpenReviews,
+		ParentTeam:            team.ParentTeam,
+		Members:               teamMembers(team.Members),
+	}, nil
+}
+
+// GetTeamTree возвращает команду с участниками всего ее поддерева; каждый участник указывается один раз
+func (s *TeamService) GetTeamTree(ctx context.Context, teamName string) (*domain.TeamResponse, error) {
+	team, err := s.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		s.logger.Error("Failed to get team", "error", err)
+		return nil, err
+	}
+
+	hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+	if err != nil {
+		s.logger.Error("Failed to load team hierarchy", "error", err)
+		return nil, err
+	}
+
+	teams := hierarchy.subtree(teamName)
+	members, err := subtreeMembers(ctx, s.repo, teams, false)
+	if err != nil {
+		s.logger.Error("Failed to get subtree members", "error", err)
+		return nil, err
+	}
+
+	return &domain.TeamResponse{
+		TeamName:              team.TeamName,
+		DefaultMaxOpenReviews: team.DefaultMaxOpenReviews,
+		ParentTeam:            team.ParentTeam,
+		Members:               teamMembers(members),
+		SubTeams:              teams[1:],
+	}, nil
+}
+
+// ListTeams возвращает все команды или, если задан root, только его поддерево
+func (s *TeamService) ListTeams(ctx context.Context, root string) ([]domain.TeamSummary, error) {
+	hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+	if err != nil {
+		s.logger.Error("Failed to load team hierarchy", "error", err)
+		return nil, err
+	}
+
+	var names []string
+	if root != "" {
+		if !hierarchy.exists(root) {
+			return nil, domain.ErrTeamNotFound
+		}
+		names = hierarchy.subtree(root)
+	} else {
+		names = make([]string, 0, len(hierarchy.parents))
+		for _, t := range hierarchy.roots() {
+			names = append(names, hierarchy.subtree(t)...)
+		}
+	}
+
+	result := make([]domain.TeamSummary, 0, len(names))
+	for _, name := range names {
+		members, err := s.repo.GetUsersByTeam(ctx, name)
+		if err != nil {
+			s.logger.Error("Failed to get team members", "team_name", name, "error", err)
+			return nil, err
+		}
+
+		result = append(result, domain.TeamSummary{
+			TeamName:    name,
+			ParentTeam:  hierarchy.parents[name],
+			Depth:       hierarchy.depth(name),
+			MemberCount: len(members),
+		})
+	}
+
+	return result, nil
+}
+
+// SetParentTeam переносит команду под другую родительскую команду или делает ее корневой.
+// Родитель должен существовать и не может быть самой командой или ее потомком.
+func (s *TeamService) SetParentTeam(ctx context.Context, req domain.SetParentTeamRequest) (*domain.TeamResponse, error) {
+	var result *domain.TeamResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+		if err != nil {
+			s.logger.Error("Failed to load team hierarchy", "error", err)
+			return err
+		}
+
+		if !hierarchy.exists(req.TeamName) {
+			return domain.ErrTeamNotFound
+		}
+		if req.ParentTeam != "" {
+			if !hierarchy.exists(req.ParentTeam) || slices.Contains(hierarchy.subtree(req.TeamName), req.ParentTeam) {
+				return domain.ErrInvalidParentTeam
+			}
+		}
+
+		if err := s.repo.SetTeamParent(ctx, req.TeamName, req.ParentTeam); err != nil {
+			s.logger.Error("Failed to set parent team", "error", err)
+			return err
+		}
+
+		result, err = s.GetTeam(ctx, req.TeamName)
+		return err
+	})
+
+	return result, err
+}
+
+// RenameTeam переименовывает команду вместе со всеми ссылками на нее.
+// Стратегии выбора из конфигурации привязаны к имени и к новому имени не переносятся.
+func (s *TeamService) RenameTeam(ctx context.Context, req domain.RenameTeamRequest) (*domain.TeamResponse, error) {
+	if strings.TrimSpace(req.NewTeamName) == "" {
+		return nil, domain.ErrInvalidTeamName
+	}
+
+	var result *domain.TeamResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if req.NewTeamName != req.TeamName {
+			if err := s.repo.RenameTeam(ctx, req.TeamName, req.NewTeamName); err != nil {
+				s.logger.Error("Failed to rename team", "error", err)
+				return err
+			}
+
+			if err := s.replaceTeamReferences(ctx, req.TeamName, req.NewTeamName); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		result, err = s.GetTeam(ctx, req.NewTeamName)
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteTeam удаляет команду без участников и дочерних команд.
+// PR команды переходят в основную команду автора, ссылки из настроек других команд удаляются.
+func (s *TeamService) DeleteTeam(ctx context.Context, req domain.DeleteTeamRequest) error {
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.repo.GetTeam(ctx, req.TeamName)
+		if err != nil {
+			s.logger.Error("Failed to get team", "error", err)
+			return err
+		}
+
+		hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+		if err != nil {
+			s.logger.Error("Failed to load team hierarchy", "error", err)
+			return err
+		}
+
+		if len(team.Members) > 0 || len(hierarchy.children[req.TeamName]) > 0 {
+			return domain.ErrTeamNotEmpty
+		}
+
+		if err := s.repo.MoveTeamPRs(ctx, req.TeamName, ""); err != nil {
+			s.logger.Error("Failed to detach team PRs", "error", err)
+			return err
+		}
+
+		if err := s.repo.DeleteTeam(ctx, req.TeamName); err != nil {
+			s.logger.Error("Failed to delete team", "error", err)
+			return err
+		}
+
+		return s.replaceTeamReferences(ctx, req.TeamName, "")
+	})
+}
+
+// MergeTeams вливает source_team в target_team: участники, PR, дочерние команды и правила
+// владения кодом переходят в target_team, после чего source_team удаляется.
+// Ревьюверы открытых PR не меняются: бывшие участники source_team теперь состоят в target_team.
+// Действуют настройки target_team, настройки source_team удаляются.
+func (s *TeamService) MergeTeams(ctx context.Context, req domain.MergeTeamsRequest) (*domain.MergeTeamsResponse, error) {
+	var result *domain.MergeTeamsResponse
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+		if err != nil {
+			s.logger.Error("Failed to load team hierarchy", "error", err)
+			return err
+		}
+
+		if !hierarchy.exists(req.SourceTeam) || !hierarchy.exists(req.TargetTeam) {
+			return domain.ErrTeamNotFound
+		}
+		// Дочерние команды source_team переходят в target_team, поэтому она не может быть потомком
+		if slices.Contains(hierarchy.subtree(req.SourceTeam), req.TargetTeam) {
+			return domain.ErrInvalidMergeTarget
+		}
+
+		members, err := s.repo.GetUsersByTeam(ctx, req.SourceTeam)
+		if err != nil {
+			s.logger.Error("Failed to get team members", "error", err)
+			return err
+		}
+
+		moved := make([]string, 0, len(members))
+		for _, member := range members {
+			if err := s.repo.AddTeamMember(ctx, req.TargetTeam, member.UserID); err != nil {
+				s.logger.Error("Failed to add team member", "user_id", member.UserID, "error", err)
+				return err
+			}
+			if member.TeamName == req.SourceTeam {
+				if err := s.repo.SetUserTeam(ctx, member.UserID, req.TargetTeam); err != nil {
+					s.logger.Error("Failed to set primary team", "user_id", member.UserID, "error", err)
+					return err
+				}
+			}
+			moved = append(moved, member.UserID)
+		}
+		slices.Sort(moved)
+
+		if err := s.repo.MoveTeamPRs(ctx, req.SourceTeam, req.TargetTeam); err != nil {
+			s.logger.Error("Failed to move team PRs", "error", err)
+			return err
+		}
+
+		for _, child := range hierarchy.children[req.SourceTeam] {
+			if err := s.repo.SetTeamParent(ctx, child, req.TargetTeam); err != nil {
+				s.logger.Error("Failed to set parent team", "team_name", child, "error", err)
+				return err
+			}
+		}
+
+		if err := s.mergeCodeOwnerRules(ctx, req.SourceTeam, req.TargetTeam); err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteTeam(ctx, req.SourceTeam); err != nil {
+			s.logger.Error("Failed to delete team", "error", err)
+			return err
+		}
+
+		if err := s.replaceTeamReferences(ctx, req.SourceTeam, req.TargetTeam); err != nil {
+			return err
+		}
+
+		team, err := s.GetTeam(ctx, req.TargetTeam)
+		if err != nil {
+			return err
+		}
+
+		result = &domain.MergeTeamsResponse{
+			SourceTeam:   req.SourceTeam,
+			Team:         team,
+			MovedMembers: moved,
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// mergeCodeOwnerRules ставит правила source перед правилами target,
+// чтобы при совпадении шаблонов побеждали правила target
+func (s *TeamService) mergeCodeOwnerRules(ctx context.Context, source, target string) error {
+	sourceRules, err := s.repo.GetCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, source)
+	if err != nil {
+		s.logger.Error("Failed to get code owner rules", "team_name", source, "error", err)
+		return err
+	}
+	if len(sourceRules) == 0 {
+		return nil
+	}
+
+	targetRules, err := s.repo.GetCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, target)
+	if err != nil {
+		s.logger.Error("Failed to get code owner rules", "team_name", target, "error", err)
+		return err
+	}
+
+	merged := make([]domain.CodeOwnerRule, 0, len(sourceRules)+len(targetRules))
+	for _, rule := range append(sourceRules, targetRules...) {
+		rule.ID = 0
+		rule.ScopeName = target
+		rule.Position = len(merged)
+		merged = append(merged, rule)
+	}
+
+	if err := s.repo.ReplaceCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, target, merged); err != nil {
+		s.logger.Error("Failed to save code owner rules", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// replaceTeamReferences заменяет oldName на newName в резервных командах и политике merge
+// всех команд; пустое newName удаляет ссылки. Вызывается внутри транзакции.
+func (s *TeamService) replaceTeamReferences(ctx context.Context, oldName, newName string) error {
+	teams, err := s.repo.ListTeams(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list teams", "error", err)
+		return err
+	}
+
+	for _, team := range teams {
+		settings, err := s.repo.GetTeamSettings(ctx, team.TeamName)
+		if err == domain.ErrTeamSettingsNotFound {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Failed to get team settings", "team_name", team.TeamName, "error", err)
+			return err
+		}
+
+		changed := false
+		fallbacks := make([]string, 0, len(settings.FallbackTeams))
+		for _, fallback := range settings.FallbackTeams {
+			if fallback == oldName {
+				fallback = newName
+				changed = true
+			}
+			// После слияния команда может оказаться резервной самой себе или встретиться дважды
+			if fallback == "" || fallback == team.TeamName || slices.Contains(fallbacks, fallback) {
+				continue
+			}
+			fallbacks = append(fallbacks, fallback)
+		}
+
+		if settings.MergePolicy.RequiredApprovalTeam == oldName {
+			settings.MergePolicy.RequiredApprovalTeam = newName
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		settings.FallbackTeams = fallbacks
+		if err := s.repo.SaveTeamSettings(ctx, settings); err != nil {
+			s.logger.Error("Failed to save team settings", "team_name", team.TeamName, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportTeams импортирует команды и участников из CSV или YAML и возвращает отчет по каждой строке.
+// Строки проверяются по порядку; в режиме upsert изменения применяются в одной транзакции
+// и только если ни одна строка не отклонена. Существующие пользователи обновляются
+// и добавляются в команду с сохранением основной команды, как в AddMembers.
+func (s *TeamService) ImportTeams(ctx context.Context, req domain.ImportTeamsRequest) (*domain.ImportTeamsResponse, error) {
+	if !req.Mode.Valid() {
+		return nil, domain.ErrInvalidImportMode
+	}
+
+	rows, err := parseImport(req.Format, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportTeamsResponse{
+		Mode: req.Mode,
+		Rows: make([]domain.ImportRowResult, 0, len(rows)),
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		hierarchy, err := loadTeamHierarchy(ctx, s.repo)
+		if err != nil {
+			s.logger.Error("Failed to load team hierarchy", "error", err)
+			return err
+		}
+
+		plan := newImportPlan(hierarchy)
+		for _, row := range rows {
+			report, err := s.planImportRow(ctx, plan, row)
+			if err != nil {
+				return err
+			}
+
+			switch report.Action {
+			case domain.ImportActionCreated:
+				result.Summary.Created++
+			case domain.ImportActionUpdated:
+				result.Summary.Updated++
+			case domain.ImportActionUnchanged:
+				result.Summary.Unchanged++
+			case domain.ImportActionRejected:
+				result.Summary.Rejected++
+			}
+			result.Rows = append(result.Rows, report)
+		}
+
+		if req.Mode != domain.ImportModeUpsert || result.Summary.Rejected > 0 {
+			return nil
+		}
+
+		if err := s.applyImportPlan(ctx, plan); err != nil {
+			return err
+		}
+		result.Applied = true
+
+		// Деактивированные импортом пользователи снимаются с ревью так же, как в /team/deactivateUsers
+		if len(plan.deactivated) > 0 {
+			result.ReassignedPRs, err = s.reassignReviews(ctx, plan.deactivated, "", domain.PREventReasonDeactivation)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// planImportRow проверяет строку импорта и, если она принята, вносит ее в план
+func (s *TeamService) planImportRow(ctx context.Context, plan *importPlan, row importRow) (domain.ImportRowResult, error) {
+	report := domain.ImportRowResult{
+		Row:      row.line,
+		TeamName: row.teamName,
+		UserID:   row.userID,
+	}
+	reject := func(problem string) (domain.ImportRowResult, error) {
+		report.Action = domain.ImportActionRejected
+		report.Error = problem
+		return report, nil
+	}
+
+	if row.problem != "" {
+		return reject(row.problem)
+	}
+
+	teamAction, problem := plan.planTeam(row)
+	if teamAction == domain.ImportActionRejected {
+		return reject(problem)
+	}
+
+	if row.userID == "" {
+		plan.applyTeam(row, teamAction)
+		report.Action = teamAction
+		return report, nil
+	}
+
+	userAction, user, deactivating, problem, err := s.planImportMember(ctx, plan, row)
+	if err != nil {
+		return report, err
+	}
+	if userAction == domain.ImportActionRejected {
+		return reject(problem)
+	}
+
+	plan.applyTeam(row, teamAction)
+	plan.applyMember(row, user, deactivating)
+
+	report.Action = userAction
+	if teamAction != domain.ImportActionUnchanged {
+		report.TeamAction = teamAction
+	}
+
+	return report, nil
 }
 
-func NewTeamService(repo storage.Repository, tx domain.TransactionManager, logger logger.Logger) *TeamService {
-	return &TeamService{
-		repo:   repo,
-		tx:     tx,
-		logger: logger,
+// planImportMember проверяет участника строки и возвращает действие над ним, пользователя
+// в том виде, в каком его сохранит импорт, и признак деактивации активного сейчас пользователя.
+// Без is_active активность существующего пользователя не меняется. План не меняется.
+func (s *TeamService) planImportMember(ctx context.Context, plan *importPlan, row importRow) (domain.ImportAction, *domain.User, bool, string, error) {
+	if row.username == "" {
+		return domain.ImportActionRejected, nil, false, "username is required", nil
+	}
+	if plan.memberships[row.teamName][row.userID] {
+		return domain.ImportActionRejected, nil, false, "user is listed in the team twice", nil
+	}
+
+	if user, planned := plan.users[row.userID]; planned {
+		if user.Username != row.username || (row.isActive != nil && user.IsActive != *row.isActive) {
+			return domain.ImportActionRejected, nil, false, "conflicting username or is_active for user listed above", nil
+		}
+	}
+
+	existing, err := s.repo.GetUser(ctx, row.userID)
+	if err == domain.ErrUserNotFound {
+		if user, planned := plan.users[row.userID]; planned {
+			return domain.ImportActionUpdated, user, false, "", nil
+		}
+		return domain.ImportActionCreated, &domain.User{
+			UserID:   row.userID,
+			Username: row.username,
+			TeamName: row.teamName,
+			IsActive: row.isActive == nil || *row.isActive,
+		}, false, "", nil
 	}
+	if err != nil {
+		s.logger.Error("Failed to get user", "user_id", row.userID, "error", err)
+		return "", nil, false, "", err
+	}
+
+	teams, err := s.repo.GetUserTeams(ctx, row.userID)
+	if err != nil {
+		s.logger.Error("Failed to get user teams", "user_id", row.userID, "error", err)
+		return "", nil, false, "", err
+	}
+
+	isActive := existing.IsActive
+	if row.isActive != nil {
+		isActive = *row.isActive
+	}
+
+	action := domain.ImportActionUnchanged
+	if existing.Username != row.username || existing.IsActive != isActive || !slices.Contains(teams, row.teamName) {
+		action = domain.ImportActionUpdated
+	}
+
+	deactivating := existing.IsActive && !isActive
+	existing.Username = row.username
+	existing.IsActive = isActive
+	if existing.TeamName == "" {
+		existing.TeamName = row.teamName
+	}
+	if user, planned := plan.users[row.userID]; planned {
+		existing = user
+	}
+
+	return action, existing, deactivating, "", nil
 }
 
-func (s *TeamService) CreateTeam(ctx context.Context, req domain.CreateTeamRequest) (*domain.TeamResponse, error) {
-	var result *domain.TeamResponse
+// applyImportPlan сохраняет принятые строки импорта; вызывается внутри транзакции
+func (s *TeamService) applyImportPlan(ctx context.Context, plan *importPlan) error {
+	for i := range plan.newTeams {
+		if err := s.repo.CreateTeam(ctx, &plan.newTeams[i], nil); err != nil {
+			s.logger.Error("Failed to create team", "team_name", plan.newTeams[i].TeamName, "error", err)
+			return err
+		}
+	}
+
+	for teamName, parentTeam := range plan.parentChanges {
+		if err := s.repo.SetTeamParent(ctx, teamName, parentTeam); err != nil {
+			s.logger.Error("Failed to set parent team", "team_name", teamName, "error", err)
+			return err
+		}
+	}
+
+	for _, userID := range plan.userOrder {
+		if err := s.repo.CreateOrUpdateUser(ctx, plan.users[userID]); err != nil {
+			s.logger.Error("Failed to save user", "user_id", userID, "error", err)
+			return err
+		}
+	}
+
+	for _, m := range plan.newMembers {
+		if err := s.repo.AddTeamMember(ctx, m.TeamName, m.UserID); err != nil {
+			s.logger.Error("Failed to add team member", "team_name", m.TeamName, "user_id", m.UserID, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// teamMembers переводит пользователей в участников команды для ответа
+func teamMembers(users []domain.User) []domain.TeamMember {
+	members := make([]domain.TeamMember, len(users))
+	for i, m := range users {
+		members[i] = domain.TeamMember{
+			UserID:         m.UserID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
+			Skills:         m.Skills,
+			Level:          m.Level,
+		}
+	}
+	return members
+}
+
+func (s *TeamService) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	if _, err := s.repo.GetTeam(ctx, teamName); err != nil {
+		s.logger.Error("Failed to get team", "error", err)
+		return nil, err
+	}
+
+	return s.assigner.teamSettings(ctx, teamName)
+}
+
+func (s *TeamService) SetTeamSettings(ctx context.Context, req domain.SetTeamSettingsRequest) (*domain.TeamSettings, error) {
+	var result *domain.TeamSettings
 
 	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
-		exists, err := s.repo.TeamExists(ctx, req.TeamName)
+		settings, err := s.GetTeamSettings(ctx, req.TeamName)
 		if err != nil {
-			s.logger.Error("Failed to check team existence", "error", err)
 			return err
 		}
-		if exists {
-			return domain.ErrTeamAlreadyExists
+
+		if req.MinReviewers != nil {
+			settings.MinReviewers = *req.MinReviewers
+		}
+		if req.MaxReviewers != nil {
+			settings.MaxReviewers = *req.MaxReviewers
 		}
 
-		team := &domain.Team{
-			TeamName: req.TeamName,
+		if settings.MaxReviewers < 1 || settings.MinReviewers < 0 || settings.MinReviewers > settings.MaxReviewers {
+			return domain.ErrInvalidTeamSettings
 		}
 
-		members := make([]domain.User, len(req.Members))
-		for i, m := range req.Members {
-			members[i] = domain.User{
-				UserID:   m.UserID,
-				Username: m.Username,
-				TeamName: req.TeamName,
-				IsActive: m.IsActive,
+		if req.ReviewersBySize != nil {
+			for size, n := range req.ReviewersBySize {
+				if !size.Valid() || n < 1 {
+					return domain.ErrInvalidReviewersBySize
+				}
 			}
+			settings.ReviewersBySize = req.ReviewersBySize
 		}
 
-		if err := s.repo.CreateTeam(ctx, team, members); err != nil {
-			s.logger.Error("Failed to create team", "error", err)
-			return err
+		if req.SizeThresholds != nil {
+			thresholds, ok := domain.MergeSizeThresholds(settings.SizeThresholds, req.SizeThresholds)
+			if !ok {
+				return domain.ErrInvalidSizeThresholds
+			}
+			settings.SizeThresholds = thresholds
 		}
 
-		responseMembers := make([]domain.TeamMember, len(req.Members))
-		for i, m := range req.Members {
-			responseMembers[i] = domain.TeamMember{
-				UserID:   m.UserID,
-				Username: m.Username,
-				IsActive: m.IsActive,
+		if req.RequireSenior != nil {
+			settings.RequireSenior = *req.RequireSenior
+		}
+
+		if req.ReviewSLA != nil {
+			if req.ReviewSLA.FirstResponseHours < 0 || (req.ReviewSLA.Action != "" && !req.ReviewSLA.Action.Valid()) {
+				return domain.ErrInvalidReviewSLA
 			}
+			settings.ReviewSLA = *req.ReviewSLA
 		}
 
-		result = &domain.TeamResponse{
-			TeamName: req.TeamName,
-			Members:  responseMembers,
+		if req.MergePolicy != nil {
+			if err := s.validateMergePolicy(ctx, *req.MergePolicy); err != nil {
+				return err
+			}
+			settings.MergePolicy = *req.MergePolicy
 		}
 
+		if req.FallbackTeams != nil {
+			if err := s.validateFallbackTeams(ctx, req.TeamName, req.FallbackTeams); err != nil {
+				return err
+			}
+			settings.FallbackTeams = req.FallbackTeams
+		}
+
+		if err := s.repo.SaveTeamSettings(ctx, settings); err != nil {
+			s.logger.Error("Failed to save team settings", "error", err)
+			return err
+		}
+
+		result = settings
+
 		return nil
 	})
 
 	return result, err
 }
 
-func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.TeamResponse, error) {
-	team, err := s.repo.GetTeam(ctx, teamName)
-	if err != nil {
-		s.logger.Error("Failed to get team", "error", err)
-		return nil, err
-	}
+func (s *TeamService) validateFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	seen := make(map[string]bool, len(fallbackTeams))
+	for _, fallback := range fallbackTeams {
+		if fallback == teamName || seen[fallback] {
+			return domain.ErrInvalidFallbackTeam
+		}
+		seen[fallback] = true
 
-	members := make([]domain.TeamMember, len(team.Members))
-	for i, m := range team.Members {
-		members[i] = domain.TeamMember{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+		_, err := s.repo.GetTeam(ctx, fallback)
+		if err == domain.ErrTeamNotFound {
+			return domain.ErrInvalidFallbackTeam
+		}
+		if err != nil {
+			return err
 		}
 	}
 
-	return &domain.TeamResponse{
-		TeamName: team.TeamName,
-		Members:  members,
-	}, nil
+	return nil
+}
+
+func (s *TeamService) validateMergePolicy(ctx context.Context, policy domain.MergePolicy) error {
+	if policy.MinApprovals < 0 {
+		return domain.ErrInvalidMergePolicy
+	}
+
+	if policy.RequiredApprovalTeam == "" {
+		return nil
+	}
+
+	_, err := s.repo.GetTeam(ctx, policy.RequiredApprovalTeam)
+	if err == domain.ErrTeamNotFound {
+		return domain.ErrInvalidMergePolicy
+	}
+
+	return err
 }
 
 func (s *TeamService) DeactivateTeamUsers(ctx context.Context, req domain.DeactivateTeamUsersRequest) (*domain.DeactivateTeamUsersResponse, error) {
@@ -109,12 +1118,16 @@ func (s *TeamService) DeactivateTeamUsers(ctx context.Context, req domain.Deacti
 			return err
 		}
 
-		reassignments, summaries := s.planReviewerReassignments(ctx, prs, reviewersMap, validUserIDs)
+		reassignments, summaries, records := s.planReviewerReassignments(ctx, prs, reviewersMap, validUserIDs)
 
 		if err := s.applyDeactivationChanges(ctx, validUserIDs, reassignments); err != nil {
 			return err
 		}
 
+		if err := s.saveAssignmentRecords(ctx, records); err != nil {
+			return err
+		}
+
 		result = &domain.DeactivateTeamUsersResponse{
 			DeactivatedUsers: validUserIDs,
 			ReassignedPRs:    summaries,
@@ -126,13 +1139,62 @@ func (s *TeamService) DeactivateTeamUsers(ctx context.Context, req domain.Deacti
 	return result, err
 }
 
+// ReassignOpenReviews снимает пользователей с открытых PR без деактивации, например на время отпуска
+func (s *TeamService) ReassignOpenReviews(ctx context.Context, userIDs []string) ([]domain.PRReassignmentSummary, error) {
+	var result []domain.PRReassignmentSummary
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.reassignReviews(ctx, userIDs, "", domain.PREventReasonOutOfOffice)
+		return err
+	})
+
+	return result, err
+}
+
+// reassignReviews снимает пользователей с открытых PR и подбирает замены; вызывается внутри транзакции.
+// Непустой teamName ограничивает переназначение PR этой команды.
+func (s *TeamService) reassignReviews(ctx context.Context, userIDs []string, teamName, reason string) ([]domain.PRReassignmentSummary, error) {
+	prs, reviewersMap, err := s.repo.GetOpenPRsWithReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if teamName != "" {
+		teamPRs := make([]domain.PullRequest, 0, len(prs))
+		for _, pr := range prs {
+			prTeam, err := s.assigner.prTeam(ctx, &pr)
+			if err != nil {
+				s.logger.Error("Failed to get PR team", "pr_id", pr.PullRequestID, "error", err)
+				return nil, err
+			}
+			if prTeam == teamName {
+				teamPRs = append(teamPRs, pr)
+			}
+		}
+		prs = teamPRs
+	}
+
+	reassignments, summaries, records := s.planReviewerReassignments(ctx, prs, reviewersMap, userIDs)
+
+	if err := s.bulkReassign(ctx, reassignments, reason); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveAssignmentRecords(ctx, records); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
 func (s *TeamService) getValidTeamUserIDsForDeactivation(ctx context.Context, req domain.DeactivateTeamUsersRequest) ([]string, error) {
 	_, err := s.repo.GetTeam(ctx, req.TeamName)
 	if err != nil {
 		return nil, err
 	}
 
-	validUserIDs := s.filterValidTeamUsers(ctx, req)
+	validUserIDs := s.filterValidTeamUsers(ctx, req.TeamName, req.UserIDs)
 	if len(validUserIDs) == 0 {
 		return nil, domain.NewAppError(domain.ErrCodeBadRequest, "no valid users to deactivate")
 	}
@@ -140,23 +1202,45 @@ func (s *TeamService) getValidTeamUserIDsForDeactivation(ctx context.Context, re
 	return validUserIDs, nil
 }
 
-func (s *TeamService) planReviewerReassignments(ctx context.Context, prs []domain.PullRequest, reviewersMap map[string][]string, deactivatingUserIDs []string) ([]domain.PRReassignment, []domain.PRReassignmentSummary) {
+func (s *TeamService) planReviewerReassignments(ctx context.Context, prs []domain.PullRequest, reviewersMap map[string][]string, deactivatingUserIDs []string) ([]domain.PRReassignment, []domain.PRReassignmentSummary, []domain.AssignmentRecord) {
 	// Планируем переназначения ревьюверов для всех PR
+	ctx = withPendingLoad(ctx)
 	deactivatingSet := s.createUserIDSet(deactivatingUserIDs)
 	reassignments := make([]domain.PRReassignment, 0)
 	summaries := make([]domain.PRReassignmentSummary, 0)
+	records := make([]domain.AssignmentRecord, 0)
 
 	// Проходим по всем PR и планируем переназначения
 	for _, pr := range prs {
-		prReassignments, summary := s.processPRReassignments(ctx, pr, reviewersMap[pr.PullRequestID], deactivatingSet)
+		prCtx := withAssignmentTrace(ctx, pr.PullRequestID, pr.AuthorID, reviewersMap[pr.PullRequestID])
+		prReassignments, summary := s.processPRReassignments(prCtx, pr, reviewersMap[pr.PullRequestID], deactivatingSet)
 		reassignments = append(reassignments, prReassignments...)
 
 		if len(summary.OldReviewers) > 0 {
 			summaries = append(summaries, summary)
 		}
+
+		newReviewerIDs := make([]string, 0, len(prReassignments))
+		for _, r := range prReassignments {
+			newReviewerIDs = append(newReviewerIDs, r.NewReviewerID)
+		}
+		records = append(records, traceRecords(prCtx, newReviewerIDs)...)
+	}
+
+	return reassignments, summaries, records
+}
+
+func (s *TeamService) saveAssignmentRecords(ctx context.Context, records []domain.AssignmentRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	if err := s.repo.SaveAssignmentRecords(ctx, records); err != nil {
+		s.logger.Error("Failed to save assignment records", "error", err)
+		return err
 	}
 
-	return reassignments, summaries
+	return nil
 }
 
 func (s *TeamService) processPRReassignments(ctx context.Context, pr domain.PullRequest, currentReviewers []string, deactivatingSet map[string]bool) ([]domain.PRReassignment, domain.PRReassignmentSummary) {
@@ -167,9 +1251,23 @@ func (s *TeamService) processPRReassignments(ctx context.Context, pr domain.Pull
 		return nil, domain.PRReassignmentSummary{}
 	}
 
+	prTeam, err := s.assigner.prTeam(ctx, &pr)
+	if err != nil {
+		s.logger.Error("Failed to get PR team", "pr_id", pr.PullRequestID, "error", err)
+		return nil, domain.PRReassignmentSummary{}
+	}
+
+	settings, err := s.assigner.teamSettings(ctx, prTeam)
+	if err != nil {
+		s.logger.Error("Failed to get team settings", "pr_id", pr.PullRequestID, "error", err)
+		return nil, domain.PRReassignmentSummary{}
+	}
+	settings = settings.ForSize(pr.Size)
+
 	reassignments := make([]domain.PRReassignment, 0)
 	oldReviewers := make([]string, 0)
 	newReviewers := make([]string, 0)
+	fallbackReviewers := make([]string, 0)
 
 	// Отслеживаем уже назначенных ревьюверов
 	assignedReviewers := make(map[string]bool)
@@ -182,6 +1280,19 @@ func (s *TeamService) processPRReassignments(ctx context.Context, pr domain.Pull
 		}
 	}
 
+	// Заменяем снятых ревьюверов, не превышая максимум команды PR
+	slots := replacementSlots(settings, len(newReviewers), len(currentReviewers)-len(newReviewers))
+
+	// Если команда требует старшего ревьювера, а среди оставшихся его нет, первая замена должна быть старшей
+	needSenior := false
+	if settings.RequireSenior {
+		hasSenior, err := s.assigner.hasSeniorReviewer(ctx, newReviewers)
+		if err != nil {
+			s.logger.Error("Failed to check senior reviewers", "pr_id", pr.PullRequestID, "error", err)
+		}
+		needSenior = err == nil && !hasSenior
+	}
+
 	// Обрабатываем деактивируемых ревьюверов
 	for _, reviewerID := range currentReviewers {
 		if !deactivatingSet[reviewerID] {
@@ -189,13 +1300,38 @@ func (s *TeamService) processPRReassignments(ctx context.Context, pr domain.Pull
 		}
 
 		oldReviewers = append(oldReviewers, reviewerID)
-		replacement := s.findReviewerReplacement(ctx, reviewerID, author, assignedReviewers, deactivatingSet)
+		replacement := ""
+		fromFallback := false
+		if needSenior {
+			replacement, fromFallback = s.findSeniorReplacement(ctx, settings, reviewerID, prTeam, author, assignedReviewers, deactivatingSet)
+			if replacement != "" {
+				needSenior = false
+			}
+		}
+		if replacement == "" && slots > 0 {
+			replacement = s.findReviewerReplacement(ctx, reviewerID, prTeam, author, assignedReviewers, deactivatingSet)
+			if replacement == "" {
+				replacement = s.findFallbackReplacement(ctx, settings, author, assignedReviewers, deactivatingSet)
+				fromFallback = replacement != ""
+			}
+			if replacement == "" {
+				replacement = s.findAncestorReplacement(ctx, reviewerID, prTeam, author, assignedReviewers, deactivatingSet)
+			}
+		}
 
 		if replacement != "" {
 			newReviewers = append(newReviewers, replacement)
 			assignedReviewers[replacement] = true
+			if fromFallback {
+				fallbackReviewers = append(fallbackReviewers, replacement)
+			}
+			slots--
 		}
 
+		// Учитываем переназначение, чтобы следующие PR видели актуальную нагрузку
+		addPendingLoad(ctx, reviewerID, -1)
+		addPendingLoad(ctx, replacement, 1)
+
 		reassignments = append(reassignments, domain.PRReassignment{
 			PullRequestID: pr.PullRequestID,
 			OldReviewerID: reviewerID,
@@ -203,36 +1339,166 @@ func (s *TeamService) processPRReassignments(ctx context.Context, pr domain.Pull
 		})
 	}
 
+	if len(newReviewers) < settings.MinReviewers {
+		s.logger.Warn("PR has fewer reviewers than team minimum",
+			"pr_id", pr.PullRequestID,
+			"reviewers", len(newReviewers),
+			"min_reviewers", settings.MinReviewers)
+	}
+
+	if needSenior {
+		s.logger.Warn("PR has no senior reviewer required by team",
+			"pr_id", pr.PullRequestID,
+			"team_name", settings.TeamName)
+	}
+
 	return reassignments, domain.PRReassignmentSummary{
-		PullRequestID: pr.PullRequestID,
-		OldReviewers:  oldReviewers,
-		NewReviewers:  newReviewers,
+		PullRequestID:     pr.PullRequestID,
+		OldReviewers:      oldReviewers,
+		NewReviewers:      newReviewers,
+		FallbackReviewers: fallbackReviewers,
+		SeniorMissing:     needSenior,
 	}
 }
 
-func (s *TeamService) findReviewerReplacement(ctx context.Context, reviewerID string, author *domain.User, assignedReviewers map[string]bool, deactivatingSet map[string]bool) string {
-	// Получаем команду ревьювера
+func (s *TeamService) findReviewerReplacement(ctx context.Context, reviewerID, prTeam string, author *domain.User, assignedReviewers map[string]bool, deactivatingSet map[string]bool) string {
+	// Получаем команду, в которой ищем замену
 	reviewer, err := s.repo.GetUser(ctx, reviewerID)
 	if err != nil {
 		s.logger.Error("Failed to get reviewer", "reviewer_id", reviewerID, "error", err)
 		return ""
 	}
 
+	teamName, err := s.assigner.replacementTeam(ctx, prTeam, reviewer)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer teams", "reviewer_id", reviewerID, "error", err)
+		return ""
+	}
+
 	// Получаем активных кандидатов из команды
-	candidates, err := s.repo.GetActiveTeamMembers(ctx, reviewer.TeamName, reviewerID)
+	candidates, err := s.repo.GetActiveTeamMembers(ctx, teamName, reviewerID)
 	if err != nil {
 		s.logger.Error("Failed to get candidates", "error", err)
 		return ""
 	}
 
-	// Ищем подходящего кандидата
+	// Отбираем подходящих кандидатов и выбираем по стратегии команды
+	valid := make([]domain.User, 0, len(candidates))
 	for _, candidate := range candidates {
 		if s.isValidReplacementCandidate(candidate, author.UserID, assignedReviewers, deactivatingSet) {
-			return candidate.UserID
+			valid = append(valid, candidate)
+		}
+	}
+
+	selected, err := s.assigner.selectReviewers(ctx, author.UserID, domain.AssignmentSourceReplacement, teamName, valid, 1)
+	if err != nil && err != domain.ErrReviewersAtCapacity {
+		s.logger.Error("Failed to select replacement", "reviewer_id", reviewerID, "error", err)
+		return ""
+	}
+	if len(selected) == 0 {
+		return ""
+	}
+
+	return selected[0].UserID
+}
+
+// findAncestorReplacement ищет замену в командах выше команды деактивируемого ревьювера
+func (s *TeamService) findAncestorReplacement(ctx context.Context, reviewerID, prTeam string, author *domain.User, assignedReviewers map[string]bool, deactivatingSet map[string]bool) string {
+	reviewer, err := s.repo.GetUser(ctx, reviewerID)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer", "reviewer_id", reviewerID, "error", err)
+		return ""
+	}
+
+	teamName, err := s.assigner.replacementTeam(ctx, prTeam, reviewer)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer teams", "reviewer_id", reviewerID, "error", err)
+		return ""
+	}
+
+	exclude := map[string]bool{author.UserID: true, reviewerID: true}
+	for userID := range assignedReviewers {
+		exclude[userID] = true
+	}
+	for userID := range deactivatingSet {
+		exclude[userID] = true
+	}
+	escalated, err := s.assigner.selectFromAncestors(ctx, author.UserID, teamName, exclude, 1, nil)
+	if err != nil {
+		s.logger.Error("Failed to select replacement from parent teams", "reviewer_id", reviewerID, "error", err)
+		return ""
+	}
+	if len(escalated) == 0 {
+		return ""
+	}
+
+	return escalated[0].UserID
+}
+
+// findSeniorReplacement ищет старшего ревьювера в команде снимаемого ревьювера и команде PR,
+// затем в резервных командах PR
+func (s *TeamService) findSeniorReplacement(ctx context.Context, settings *domain.TeamSettings, reviewerID, prTeam string, author *domain.User, assignedReviewers map[string]bool, deactivatingSet map[string]bool) (string, bool) {
+	reviewer, err := s.repo.GetUser(ctx, reviewerID)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer", "reviewer_id", reviewerID, "error", err)
+		return "", false
+	}
+
+	teamName, err := s.assigner.replacementTeam(ctx, prTeam, reviewer)
+	if err != nil {
+		s.logger.Error("Failed to get reviewer teams", "reviewer_id", reviewerID, "error", err)
+		return "", false
+	}
+
+	candidates, err := s.assigner.seniorReplacementCandidates(ctx, teamName, reviewerID, prTeam, author.UserID)
+	if err != nil {
+		s.logger.Error("Failed to get candidates", "error", err)
+		return "", false
+	}
+
+	exclude := map[string]bool{author.UserID: true}
+	for userID := range assignedReviewers {
+		exclude[userID] = true
+	}
+	for userID := range deactivatingSet {
+		exclude[userID] = true
+	}
+
+	senior, fromFallback, err := s.assigner.selectSenior(ctx, author.UserID, settings, teamName, candidates, exclude)
+	if err != nil {
+		if err != domain.ErrSeniorReviewerRequired {
+			s.logger.Error("Failed to select senior replacement", "reviewer_id", reviewerID, "error", err)
 		}
+		return "", false
 	}
 
-	return ""
+	return senior.UserID, fromFallback
+}
+
+// findFallbackReplacement ищет замену в резервных командах команды PR
+func (s *TeamService) findFallbackReplacement(ctx context.Context, settings *domain.TeamSettings, author *domain.User, assignedReviewers map[string]bool, deactivatingSet map[string]bool) string {
+	if len(settings.FallbackTeams) == 0 {
+		return ""
+	}
+
+	exclude := map[string]bool{author.UserID: true}
+	for userID := range assignedReviewers {
+		exclude[userID] = true
+	}
+	for userID := range deactivatingSet {
+		exclude[userID] = true
+	}
+
+	selected, err := s.assigner.selectFromFallback(ctx, author.UserID, settings, exclude, 1, nil)
+	if err != nil {
+		s.logger.Error("Failed to select fallback replacement", "error", err)
+		return ""
+	}
+	if len(selected) == 0 {
+		return ""
+	}
+
+	return selected[0].UserID
 }
 
 func (s *TeamService) isValidReplacementCandidate(candidate domain.User, authorID string, assignedReviewers map[string]bool, deactivatingSet map[string]bool) bool {
@@ -256,11 +1522,8 @@ func (s *TeamService) isValidReplacementCandidate(candidate domain.User, authorI
 
 func (s *TeamService) applyDeactivationChanges(ctx context.Context, validUserIDs []string, reassignments []domain.PRReassignment) error {
 	// Выполняем массовое переназначение
-	if len(reassignments) > 0 {
-		if err := s.repo.BulkReassignReviewers(ctx, reassignments); err != nil {
-			s.logger.Error("Failed to bulk reassign reviewers", "error", err)
-			return err
-		}
+	if err := s.bulkReassign(ctx, reassignments, domain.PREventReasonDeactivation); err != nil {
+		return err
 	}
 
 	// Деактивируем пользователей
@@ -271,6 +1534,31 @@ func (s *TeamService) applyDeactivationChanges(ctx context.Context, validUserIDs
 	return nil
 }
 
+// bulkReassign применяет замены ревьюверов и записывает их в историю PR с причиной reason
+func (s *TeamService) bulkReassign(ctx context.Context, reassignments []domain.PRReassignment, reason string) error {
+	if len(reassignments) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if err := s.repo.BulkReassignReviewers(ctx, reassignments, now); err != nil {
+		s.logger.Error("Failed to bulk reassign reviewers", "error", err)
+		return err
+	}
+
+	events := make([]domain.PREvent, len(reassignments))
+	for i, r := range reassignments {
+		events[i] = reassignedEvent(r.PullRequestID, r.OldReviewerID, r.NewReviewerID, reason, now)
+	}
+
+	if err := addPREvents(ctx, s.repo, events); err != nil {
+		s.logger.Error("Failed to save PR events", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (s *TeamService) createUserIDSet(userIDs []string) map[string]bool {
 	set := make(map[string]bool, len(userIDs))
 	for _, userID := range userIDs {
@@ -279,14 +1567,15 @@ func (s *TeamService) createUserIDSet(userIDs []string) map[string]bool {
 	return set
 }
 
-func (s *TeamService) filterValidTeamUsers(ctx context.Context, req domain.DeactivateTeamUsersRequest) []string {
-	validUserIDs := make([]string, 0, len(req.UserIDs))
-	for _, userID := range req.UserIDs {
-		user, err := s.repo.GetUser(ctx, userID)
+// filterValidTeamUsers оставляет только пользователей, состоящих в команде
+func (s *TeamService) filterValidTeamUsers(ctx context.Context, teamName string, userIDs []string) []string {
+	validUserIDs := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		teams, err := s.repo.GetUserTeams(ctx, userID)
 		if err != nil {
 			continue
 		}
-		if user.TeamName != req.TeamName {
+		if !slices.Contains(teams, teamName) {
 			continue
 		}
 		validUserIDs = append(validUserIDs, userID)
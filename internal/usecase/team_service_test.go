@@ -23,7 +23,7 @@ func TestTeamService_CreateTeam(t *testing.T) {
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 	mockTx.On("WithinTransaction", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewTeamService(repo, mockTx, mockLogger)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	req := domain.CreateTeamRequest{
 		TeamName: "backend",
@@ -50,7 +50,7 @@ func TestTeamService_CreateTeam_AlreadyExists(t *testing.T) {
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 	mockTx.On("WithinTransaction", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewTeamService(repo, mockTx, mockLogger)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	req := domain.CreateTeamRequest{
 		TeamName: "backend",
@@ -76,7 +76,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	mockTx.On("WithinTransaction", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewTeamService(repo, mockTx, mockLogger)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	req := domain.CreateTeamRequest{
 		TeamName: "backend",
@@ -102,7 +102,7 @@ func TestTeamService_GetTeam_NotFound(t *testing.T) {
 	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
-	service := NewTeamService(repo, mockTx, mockLogger)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	_, err := service.GetTeam(context.Background(), "nonexistent")
 	assert.Error(t, err)
@@ -119,7 +119,7 @@ func TestTeamService_DeactivateTeamUsers(t *testing.T) {
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 	mockTx.On("WithinTransaction", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewTeamService(repo, mockTx, mockLogger)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	req := domain.CreateTeamRequest{
 		TeamName: "backend",
@@ -157,7 +157,7 @@ func TestTeamService_DeactivateTeamUsers_WithPRReassignment(t *testing.T) {
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
 	mockTx.On("WithinTransaction", mock.Anything, mock.Anything).Return(nil)
 
-	service := NewTeamService(repo, mockTx, mockLogger)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
 
 	teamReq := domain.CreateTeamRequest{
 		TeamName: "backend",
@@ -239,5 +239,888 @@ func TestTeamService_DeactivateTeamUsers_WithPRReassignment(t *testing.T) {
 		_, AssignedReviewers, err = repo.GetPRWithReviewers(ctx, "pr-003")
 		require.NoError(t, err)
 		assert.NotContains(t, AssignedReviewers, "u2")
+
+		// Замены попадают в историю PR с причиной deactivation
+		events, err := repo.GetPREvents(ctx, "pr-001")
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		for _, e := range events {
+			assert.Contains(t, []domain.PREventType{domain.PREventReassigned, domain.PREventReviewerRemoved}, e.Type)
+			assert.Equal(t, domain.PREventReasonDeactivation, e.Reason)
+		}
+	})
+}
+
+func TestTeamService_Membership(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	_, err := service.CreateTeam(ctx, domain.CreateTeamRequest{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	_, err = service.CreateTeam(ctx, domain.CreateTeamRequest{
+		TeamName: "frontend",
+		Members:  []domain.TeamMember{{UserID: "u5", Username: "Eve", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	t.Run("adds new and updates existing members", func(t *testing.T) {
+		team, err := service.AddMembers(ctx, domain.AddTeamMembersRequest{
+			TeamName: "backend",
+			Members: []domain.TeamMember{
+				{UserID: "u2", Username: "Bobby", IsActive: true, Skills: []string{"Go"}},
+				{UserID: "u3", Username: "Charlie", IsActive: true},
+				{UserID: "u4", Username: "David", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+		assert.Len(t, team.Members, 4)
+
+		user, err := repo.GetUser(ctx, "u2")
+		require.NoError(t, err)
+		assert.Equal(t, "Bobby", user.Username)
+		assert.Equal(t, []string{"go"}, user.Skills)
+	})
+
+	t.Run("keeps fields omitted for existing members", func(t *testing.T) {
+		limit := 3
+		require.NoError(t, repo.SetUserMaxOpenReviews(ctx, "u5", &limit))
+		require.NoError(t, repo.SetUserLevel(ctx, "u5", domain.UserLevelSenior))
+		require.NoError(t, repo.SetUserSkills(ctx, "u5", []string{"css"}))
+
+		_, err := service.AddMembers(ctx, domain.AddTeamMembersRequest{
+			TeamName: "backend",
+			Members:  []domain.TeamMember{{UserID: "u5", IsActive: true}},
+		})
+		require.NoError(t, err)
+
+		user, err := repo.GetUser(ctx, "u5")
+		require.NoError(t, err)
+		assert.Equal(t, "Eve", user.Username)
+		assert.True(t, user.IsActive)
+		assert.Equal(t, "frontend", user.TeamName)
+		assert.Equal(t, domain.UserLevelSenior, user.Level)
+		assert.Equal(t, []string{"css"}, user.Skills)
+		require.NotNil(t, user.MaxOpenReviews)
+		assert.Equal(t, 3, *user.MaxOpenReviews)
+
+		require.NoError(t, repo.RemoveTeamMember(ctx, "backend", "u5"))
+	})
+
+	t.Run("rejects unknown team", func(t *testing.T) {
+		_, err := service.AddMembers(ctx, domain.AddTeamMembersRequest{TeamName: "missing"})
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2", "u3"}))
+
+	t.Run("removing a member reassigns their reviews", func(t *testing.T) {
+		result, err := service.RemoveMembers(ctx, domain.RemoveTeamMembersRequest{TeamName: "backend", UserIDs: []string{"u2", "u5"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, result.RemovedUsers)
+		require.Len(t, result.ReassignedPRs, 1)
+		assert.Equal(t, []string{"u2"}, result.ReassignedPRs[0].OldReviewers)
+		assert.ElementsMatch(t, []string{"u3", "u4"}, result.ReassignedPRs[0].NewReviewers)
+
+		user, err := repo.GetUser(ctx, "u2")
+		require.NoError(t, err)
+		assert.Empty(t, user.TeamName)
+		assert.True(t, user.IsActive)
+
+		events, err := repo.GetPREvents(ctx, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.PREventReasonTeamChange, events[0].Reason)
+
+		_, err = service.RemoveMembers(ctx, domain.RemoveTeamMembersRequest{TeamName: "backend", UserIDs: []string{"u5"}})
+		assert.Equal(t, domain.ErrNoMembersToRemove, err)
+	})
+
+	t.Run("removed user can be added again", func(t *testing.T) {
+		_, err := service.AddMembers(ctx, domain.AddTeamMembersRequest{
+			TeamName: "frontend",
+			Members:  []domain.TeamMember{{UserID: "u2", Username: "Bobby", IsActive: true}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("moving a member reassigns their reviews", func(t *testing.T) {
+		result, err := service.MoveUser(ctx, domain.MoveUserTeamRequest{UserID: "u3", TeamName: "frontend"})
+		require.NoError(t, err)
+		assert.Equal(t, "backend", result.FromTeam)
+		require.Len(t, result.ReassignedPRs, 1)
+		assert.Equal(t, []string{"u3"}, result.ReassignedPRs[0].OldReviewers)
+		assert.Equal(t, []string{"u4"}, result.ReassignedPRs[0].NewReviewers)
+
+		user, err := repo.GetUser(ctx, "u3")
+		require.NoError(t, err)
+		assert.Equal(t, "frontend", user.TeamName)
+
+		result, err = service.MoveUser(ctx, domain.MoveUserTeamRequest{UserID: "u3", TeamName: "frontend"})
+		require.NoError(t, err)
+		assert.Empty(t, result.ReassignedPRs)
+
+		_, err = service.MoveUser(ctx, domain.MoveUserTeamRequest{UserID: "u3", TeamName: "missing"})
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("adding an inactive member deactivates and reassigns their reviews", func(t *testing.T) {
+		team, err := service.AddMembers(ctx, domain.AddTeamMembersRequest{
+			TeamName: "frontend",
+			Members:  []domain.TeamMember{{UserID: "u4", Username: "David", IsActive: false}},
+		})
+		require.NoError(t, err)
+		require.Len(t, team.ReassignedPRs, 1)
+		assert.Equal(t, "pr-1", team.ReassignedPRs[0].PullRequestID)
+		assert.Equal(t, []string{"u4"}, team.ReassignedPRs[0].OldReviewers)
+
+		user, err := repo.GetUser(ctx, "u4")
+		require.NoError(t, err)
+		assert.False(t, user.IsActive)
+
+		events, err := repo.GetPREvents(ctx, "pr-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.PREventReasonDeactivation, events[len(events)-1].Reason)
+	})
+}
+
+func TestTeamService_MultipleTeams(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	_, err := service.CreateTeam(ctx, domain.CreateTeamRequest{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Charlie", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	_, err = service.CreateTeam(ctx, domain.CreateTeamRequest{TeamName: "mobile"})
+	require.NoError(t, err)
+
+	t.Run("existing user joins another team and keeps primary team", func(t *testing.T) {
+		team, err := service.CreateTeam(ctx, domain.CreateTeamRequest{
+			TeamName: "frontend",
+			Members: []domain.TeamMember{
+				{UserID: "f1", Username: "Frank", IsActive: true},
+				{UserID: "f2", Username: "Grace", IsActive: true},
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+		assert.Len(t, team.Members, 3)
+
+		user, err := repo.GetUser(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "backend", user.TeamName)
+
+		teams, err := repo.GetUserTeams(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "frontend"}, teams)
+	})
+
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-b", AuthorID: "u2", TeamName: "backend", Status: domain.PRStatusOpen}, []string{"u1", "u3"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-f", AuthorID: "f1", TeamName: "frontend", Status: domain.PRStatusOpen}, []string{"u1", "f2"}))
+
+	t.Run("removing from one team reassigns only that team's reviews", func(t *testing.T) {
+		result, err := service.RemoveMembers(ctx, domain.RemoveTeamMembersRequest{TeamName: "backend", UserIDs: []string{"u1"}})
+		require.NoError(t, err)
+		require.Len(t, result.ReassignedPRs, 1)
+		assert.Equal(t, "pr-b", result.ReassignedPRs[0].PullRequestID)
+		assert.Equal(t, []string{"u1"}, result.ReassignedPRs[0].OldReviewers)
+
+		_, reviewers, err := repo.GetPRWithReviewers(ctx, "pr-f")
+		require.NoError(t, err)
+		assert.Contains(t, reviewers, "u1")
+
+		user, err := repo.GetUser(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "frontend", user.TeamName)
+	})
+
+	t.Run("moves user out of a secondary team", func(t *testing.T) {
+		_, err := service.AddMembers(ctx, domain.AddTeamMembersRequest{
+			TeamName: "frontend",
+			Members:  []domain.TeamMember{{UserID: "u3", Username: "Charlie", IsActive: true}},
+		})
+		require.NoError(t, err)
+
+		result, err := service.MoveUser(ctx, domain.MoveUserTeamRequest{UserID: "u3", FromTeam: "frontend", TeamName: "mobile"})
+		require.NoError(t, err)
+		assert.Equal(t, "frontend", result.FromTeam)
+		assert.Empty(t, result.ReassignedPRs)
+
+		user, err := repo.GetUser(ctx, "u3")
+		require.NoError(t, err)
+		assert.Equal(t, "backend", user.TeamName)
+
+		teams, err := repo.GetUserTeams(ctx, "u3")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "mobile"}, teams)
+
+		_, err = service.MoveUser(ctx, domain.MoveUserTeamRequest{UserID: "u3", FromTeam: "frontend", TeamName: "mobile"})
+		assert.Equal(t, domain.ErrUserNotInTeam, err)
+	})
+}
+
+func TestTeamService_Hierarchy(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	for _, req := range []domain.CreateTeamRequest{
+		{TeamName: "org", Members: []domain.TeamMember{{UserID: "o1", Username: "Olivia", IsActive: true}}},
+		{TeamName: "dept", ParentTeam: "org", Members: []domain.TeamMember{{UserID: "d1", Username: "Dan", IsActive: true}}},
+		{TeamName: "squad-a", ParentTeam: "dept", Members: []domain.TeamMember{
+			{UserID: "a1", Username: "Alice", IsActive: true},
+			{UserID: "a2", Username: "Andy", IsActive: true},
+		}},
+		{TeamName: "squad-b", ParentTeam: "dept", Members: []domain.TeamMember{{UserID: "b1", Username: "Bob", IsActive: true}}},
+	} {
+		_, err := service.CreateTeam(ctx, req)
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.AddTeamMember(ctx, "squad-b", "a1"))
+
+	t.Run("rejects unknown parent", func(t *testing.T) {
+		_, err := service.CreateTeam(ctx, domain.CreateTeamRequest{TeamName: "squad-c", ParentTeam: "missing"})
+		assert.Equal(t, domain.ErrInvalidParentTeam, err)
+	})
+
+	t.Run("lists teams depth-first by subtree", func(t *testing.T) {
+		teams, err := service.ListTeams(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TeamSummary{
+			{TeamName: "org", Depth: 0, MemberCount: 1},
+			{TeamName: "dept", ParentTeam: "org", Depth: 1, MemberCount: 1},
+			{TeamName: "squad-a", ParentTeam: "dept", Depth: 2, MemberCount: 2},
+			{TeamName: "squad-b", ParentTeam: "dept", Depth: 2, MemberCount: 2},
+		}, teams)
+
+		teams, err = service.ListTeams(ctx, "dept")
+		require.NoError(t, err)
+		assert.Len(t, teams, 3)
+
+		_, err = service.ListTeams(ctx, "missing")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("rolls up subtree members once", func(t *testing.T) {
+		team, err := service.GetTeamTree(ctx, "dept")
+		require.NoError(t, err)
+		assert.Equal(t, "org", team.ParentTeam)
+		assert.Equal(t, []string{"squad-a", "squad-b"}, team.SubTeams)
+
+		userIDs := make([]string, len(team.Members))
+		for i, m := range team.Members {
+			userIDs[i] = m.UserID
+		}
+		assert.ElementsMatch(t, []string{"d1", "a1", "a2", "b1"}, userIDs)
+	})
+
+	t.Run("rolls up assignment stats", func(t *testing.T) {
+		require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "o1", Status: domain.PRStatusOpen}, []string{"a2", "b1", "d1"}))
+		require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-2", AuthorID: "a1", Status: domain.PRStatusOpen}, []string{"a2", "o1"}))
+
+		metrics := NewMetricsService(repo, mockTx, mockLogger)
+		stats, err := metrics.GetAssignmentStats(ctx, "squad-a", false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats["total_assignments"])
+
+		stats, err = metrics.GetAssignmentStats(ctx, "dept", true)
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats["total_assignments"])
+		assert.Equal(t, map[string]int{"a2": 2, "b1": 1, "d1": 1}, stats["reviewer_assignments"])
+
+		_, err = metrics.GetAssignmentStats(ctx, "missing", true)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("moves team under another parent", func(t *testing.T) {
+		_, err := service.SetParentTeam(ctx, domain.SetParentTeamRequest{TeamName: "dept", ParentTeam: "squad-a"})
+		assert.Equal(t, domain.ErrInvalidParentTeam, err)
+		_, err = service.SetParentTeam(ctx, domain.SetParentTeamRequest{TeamName: "dept", ParentTeam: "dept"})
+		assert.Equal(t, domain.ErrInvalidParentTeam, err)
+		_, err = service.SetParentTeam(ctx, domain.SetParentTeamRequest{TeamName: "missing", ParentTeam: "org"})
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+
+		team, err := service.SetParentTeam(ctx, domain.SetParentTeamRequest{TeamName: "squad-b", ParentTeam: "org"})
+		require.NoError(t, err)
+		assert.Equal(t, "org", team.ParentTeam)
+
+		team, err = service.SetParentTeam(ctx, domain.SetParentTeamRequest{TeamName: "squad-b"})
+		require.NoError(t, err)
+		assert.Empty(t, team.ParentTeam)
+	})
+}
+
+func TestTeamService_RenameDeleteMerge(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	for _, req := range []domain.CreateTeamRequest{
+		{TeamName: "platform", Members: []domain.TeamMember{{UserID: "p1", Username: "Paul", IsActive: true}}},
+		{TeamName: "backend", ParentTeam: "platform", Members: []domain.TeamMember{
+			{UserID: "b1", Username: "Bob", IsActive: true},
+			{UserID: "b2", Username: "Bill", IsActive: true},
+		}},
+		{TeamName: "api", ParentTeam: "backend", Members: []domain.TeamMember{{UserID: "a1", Username: "Alice", IsActive: true}}},
+		{TeamName: "frontend", Members: []domain.TeamMember{{UserID: "f1", Username: "Fred", IsActive: true}}},
+		{TeamName: "legacy"},
+	} {
+		_, err := service.CreateTeam(ctx, req)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, repo.SaveTeamSettings(ctx, &domain.TeamSettings{
+		TeamName:      "frontend",
+		MaxReviewers:  2,
+		FallbackTeams: []string{"backend", "legacy"},
+		MergePolicy:   domain.MergePolicy{RequiredApprovalTeam: "backend"},
+	}))
+	require.NoError(t, repo.SaveTeamSettings(ctx, &domain.TeamSettings{
+		TeamName:      "backend",
+		MaxReviewers:  2,
+		FallbackTeams: []string{"api", "frontend"},
+	}))
+	require.NoError(t, repo.ReplaceCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, "backend", []domain.CodeOwnerRule{
+		{ScopeType: domain.CodeOwnerScopeTeam, ScopeName: "backend", Position: 0, Pattern: "*.go", Owners: []string{"b1"}},
+	}))
+	require.NoError(t, repo.ReplaceCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, "api", []domain.CodeOwnerRule{
+		{ScopeType: domain.CodeOwnerScopeTeam, ScopeName: "api", Position: 0, Pattern: "*.go", Owners: []string{"a1"}},
+	}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-b", AuthorID: "b1", TeamName: "backend", Status: domain.PRStatusOpen}, []string{"b2"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-a", AuthorID: "b1", TeamName: "api", Status: domain.PRStatusOpen}, []string{"a1"}))
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-l", AuthorID: "f1", TeamName: "legacy", Status: domain.PRStatusOpen}, []string{}))
+
+	t.Run("rename updates references", func(t *testing.T) {
+		team, err := service.RenameTeam(ctx, domain.RenameTeamRequest{TeamName: "backend", NewTeamName: "core"})
+		require.NoError(t, err)
+		assert.Equal(t, "core", team.TeamName)
+		assert.Equal(t, "platform", team.ParentTeam)
+		assert.Len(t, team.Members, 2)
+
+		user, err := repo.GetUser(ctx, "b1")
+		require.NoError(t, err)
+		assert.Equal(t, "core", user.TeamName)
+
+		pr, err := repo.GetPR(ctx, "pr-b")
+		require.NoError(t, err)
+		assert.Equal(t, "core", pr.TeamName)
+
+		api, err := repo.GetTeam(ctx, "api")
+		require.NoError(t, err)
+		assert.Equal(t, "core", api.ParentTeam)
+
+		settings, err := service.GetTeamSettings(ctx, "frontend")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"core", "legacy"}, settings.FallbackTeams)
+		assert.Equal(t, "core", settings.MergePolicy.RequiredApprovalTeam)
+
+		settings, err = service.GetTeamSettings(ctx, "core")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api", "frontend"}, settings.FallbackTeams)
+
+		rules, err := repo.GetCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, "core")
+		require.NoError(t, err)
+		assert.Len(t, rules, 1)
+
+		_, err = repo.GetTeam(ctx, "backend")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("rename rejects invalid names", func(t *testing.T) {
+		_, err := service.RenameTeam(ctx, domain.RenameTeamRequest{TeamName: "core", NewTeamName: "frontend"})
+		assert.Equal(t, domain.ErrTeamAlreadyExists, err)
+		_, err = service.RenameTeam(ctx, domain.RenameTeamRequest{TeamName: "core", NewTeamName: " "})
+		assert.Equal(t, domain.ErrInvalidTeamName, err)
+		_, err = service.RenameTeam(ctx, domain.RenameTeamRequest{TeamName: "missing", NewTeamName: "other"})
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("delete requires an empty team", func(t *testing.T) {
+		assert.Equal(t, domain.ErrTeamNotEmpty, service.DeleteTeam(ctx, domain.DeleteTeamRequest{TeamName: "frontend"}))
+		assert.Equal(t, domain.ErrTeamNotFound, service.DeleteTeam(ctx, domain.DeleteTeamRequest{TeamName: "missing"}))
+
+		require.NoError(t, service.DeleteTeam(ctx, domain.DeleteTeamRequest{TeamName: "legacy"}))
+
+		_, err := repo.GetTeam(ctx, "legacy")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+
+		pr, err := repo.GetPR(ctx, "pr-l")
+		require.NoError(t, err)
+		assert.Empty(t, pr.TeamName)
+
+		settings, err := service.GetTeamSettings(ctx, "frontend")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"core"}, settings.FallbackTeams)
+	})
+
+	t.Run("merge rejects self and descendants", func(t *testing.T) {
+		_, err := service.MergeTeams(ctx, domain.MergeTeamsRequest{SourceTeam: "core", TargetTeam: "core"})
+		assert.Equal(t, domain.ErrInvalidMergeTarget, err)
+		_, err = service.MergeTeams(ctx, domain.MergeTeamsRequest{SourceTeam: "platform", TargetTeam: "api"})
+		assert.Equal(t, domain.ErrInvalidMergeTarget, err)
+		_, err = service.MergeTeams(ctx, domain.MergeTeamsRequest{SourceTeam: "core", TargetTeam: "missing"})
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("merge moves members and keeps reviews", func(t *testing.T) {
+		result, err := service.MergeTeams(ctx, domain.MergeTeamsRequest{SourceTeam: "api", TargetTeam: "core"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a1"}, result.MovedMembers)
+		assert.Len(t, result.Team.Members, 3)
+
+		user, err := repo.GetUser(ctx, "a1")
+		require.NoError(t, err)
+		assert.Equal(t, "core", user.TeamName)
+
+		pr, reviewers, err := repo.GetPRWithReviewers(ctx, "pr-a")
+		require.NoError(t, err)
+		assert.Equal(t, "core", pr.TeamName)
+		assert.Equal(t, []string{"a1"}, reviewers)
+
+		// Правила core остаются последними и побеждают при совпадении шаблонов
+		rules, err := repo.GetCodeOwnerRules(ctx, domain.CodeOwnerScopeTeam, "core")
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, []string{"a1"}, rules[0].Owners)
+		assert.Equal(t, []string{"b1"}, rules[1].Owners)
+		assert.Equal(t, 1, rules[1].Position)
+
+		settings, err := service.GetTeamSettings(ctx, "core")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frontend"}, settings.FallbackTeams)
+
+		_, err = repo.GetTeam(ctx, "api")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("merge drops self references", func(t *testing.T) {
+		require.NoError(t, repo.SaveTeamSettings(ctx, &domain.TeamSettings{
+			TeamName:      "platform",
+			MaxReviewers:  2,
+			FallbackTeams: []string{"frontend"},
+		}))
+
+		_, err := service.MergeTeams(ctx, domain.MergeTeamsRequest{SourceTeam: "frontend", TargetTeam: "platform"})
+		require.NoError(t, err)
+
+		settings, err := service.GetTeamSettings(ctx, "platform")
+		require.NoError(t, err)
+		assert.Empty(t, settings.FallbackTeams)
+
+		settings, err = service.GetTeamSettings(ctx, "core")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"platform"}, settings.FallbackTeams)
+	})
+
+	t.Run("merge into parent collapses the subtree", func(t *testing.T) {
+		_, err := service.MergeTeams(ctx, domain.MergeTeamsRequest{SourceTeam: "core", TargetTeam: "platform"})
+		require.NoError(t, err)
+
+		teams, err := service.ListTeams(ctx, "")
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, 5, teams[0].MemberCount)
+	})
+}
+
+func TestTeamService_ImportTeams(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	_, err := service.CreateTeam(ctx, domain.CreateTeamRequest{TeamName: "engineering", Members: []domain.TeamMember{{UserID: "e1", Username: "Eve", IsActive: true}}})
+	require.NoError(t, err)
+	_, err = service.CreateTeam(ctx, domain.CreateTeamRequest{TeamName: "qa", Members: []domain.TeamMember{{UserID: "q1", Username: "Quinn", IsActive: true}}})
+	require.NoError(t, err)
+
+	valid := `team_name,parent_team,user_id,username,is_active
+platform,engineering,,,
+backend,platform,u1,Alice,true
+backend,,u2,Bob,false
+backend,,e1,Eve,true
+engineering,,e1,Eve,true
+qa,,q1,Quinn,false
+qa,,u1,Alice,true
+`
+	invalid := valid + `frontend,missing,f1,Fred,
+backend,,u1,Alice,true
+platform,,u2,Bobby,false
+`
+
+	t.Run("validate reports rows without applying", func(t *testing.T) {
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: domain.ImportModeValidate, Content: invalid})
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, domain.ImportSummary{Created: 3, Updated: 3, Unchanged: 1, Rejected: 3}, result.Summary)
+
+		assert.Equal(t, domain.ImportRowResult{Row: 2, TeamName: "platform", Action: domain.ImportActionCreated}, result.Rows[0])
+		assert.Equal(t, domain.ImportRowResult{Row: 3, TeamName: "backend", UserID: "u1", Action: domain.ImportActionCreated, TeamAction: domain.ImportActionCreated}, result.Rows[1])
+		assert.Equal(t, domain.ImportActionUpdated, result.Rows[3].Action)
+		assert.Equal(t, domain.ImportActionUnchanged, result.Rows[4].Action)
+		assert.Equal(t, domain.ImportActionUpdated, result.Rows[6].Action)
+		for _, row := range result.Rows[7:] {
+			assert.Equal(t, domain.ImportActionRejected, row.Action)
+			assert.NotEmpty(t, row.Error)
+		}
+
+		_, err = repo.GetTeam(ctx, "platform")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+	})
+
+	t.Run("upsert applies nothing when rows are rejected", func(t *testing.T) {
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: domain.ImportModeUpsert, Content: invalid})
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+
+		_, err = repo.GetUser(ctx, "u1")
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+
+	t.Run("upsert applies valid import", func(t *testing.T) {
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: domain.ImportModeUpsert, Content: valid})
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+		assert.Zero(t, result.Summary.Rejected)
+
+		backend, err := service.GetTeam(ctx, "backend")
+		require.NoError(t, err)
+		assert.Equal(t, "platform", backend.ParentTeam)
+		assert.Len(t, backend.Members, 3)
+
+		platform, err := repo.GetTeam(ctx, "platform")
+		require.NoError(t, err)
+		assert.Equal(t, "engineering", platform.ParentTeam)
+
+		u1, err := repo.GetUser(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, "backend", u1.TeamName)
+		teams, err := repo.GetUserTeams(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "qa"}, teams)
+
+		// Основная команда существующего пользователя сохраняется
+		e1, err := repo.GetUser(ctx, "e1")
+		require.NoError(t, err)
+		assert.Equal(t, "engineering", e1.TeamName)
+
+		q1, err := repo.GetUser(ctx, "q1")
+		require.NoError(t, err)
+		assert.False(t, q1.IsActive)
+	})
+
+	t.Run("reimport is unchanged", func(t *testing.T) {
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: domain.ImportModeUpsert, Content: valid})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportSummary{Unchanged: 7}, result.Summary)
+	})
+
+	t.Run("yaml moves team and rejects cycles", func(t *testing.T) {
+		content := `teams:
+  - team_name: qa
+    parent_team: platform
+  - team_name: engineering
+    parent_team: backend
+`
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatYAML, Mode: domain.ImportModeValidate, Content: content})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportActionUpdated, result.Rows[0].Action)
+		assert.Equal(t, domain.ImportActionRejected, result.Rows[1].Action)
+		assert.Equal(t, 4, result.Rows[1].Row)
+	})
+
+	t.Run("missing is_active keeps existing value", func(t *testing.T) {
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: domain.ImportModeUpsert, Content: "team_name,user_id,username\nqa,q1,Quinn\n"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportSummary{Unchanged: 1}, result.Summary)
+
+		q1, err := repo.GetUser(ctx, "q1")
+		require.NoError(t, err)
+		assert.False(t, q1.IsActive)
+	})
+
+	t.Run("deactivation reassigns open reviews", func(t *testing.T) {
+		require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", TeamName: "backend", Status: domain.PRStatusOpen}, []string{"e1"}))
+
+		result, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: domain.ImportModeUpsert, Content: "team_name,user_id,username,is_active\nbackend,e1,Eve,false\n"})
+		require.NoError(t, err)
+		require.True(t, result.Applied)
+		require.Len(t, result.ReassignedPRs, 1)
+		assert.Equal(t, "pr-1", result.ReassignedPRs[0].PullRequestID)
+		assert.Equal(t, []string{"e1"}, result.ReassignedPRs[0].OldReviewers)
+
+		assigned, err := repo.IsReviewerAssigned(ctx, "pr-1", "e1")
+		require.NoError(t, err)
+		assert.False(t, assigned)
+
+		events, err := repo.GetPREvents(ctx, "pr-1")
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, domain.PREventReasonDeactivation, events[len(events)-1].Reason)
+	})
+
+	t.Run("rejects invalid mode", func(t *testing.T) {
+		_, err := service.ImportTeams(ctx, domain.ImportTeamsRequest{Format: domain.ImportFormatCSV, Mode: "apply", Content: valid})
+		assert.Equal(t, domain.ErrInvalidImportMode, err)
+	})
+}
+
+func TestTeamService_DeactivateTeamUsers_LeastLoaded(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	selectors, err := NewReviewerSelectors(repo, domain.SelectionStrategyLeastLoaded, nil, DefaultPairingHistoryOptions())
+	require.NoError(t, err)
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, selectors, domain.CapacityPolicyFewer), mockLogger)
+
+	teamReq := domain.CreateTeamRequest{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Charlie", IsActive: true},
+			{UserID: "u4", Username: "David", IsActive: true},
+		},
+	}
+	_, err = service.CreateTeam(ctx, teamReq)
+	require.NoError(t, err)
+
+	for _, prID := range []string{"pr-1", "pr-2"} {
+		err = repo.CreatePR(ctx, &domain.PullRequest{
+			PullRequestID: prID,
+			AuthorID:      "u1",
+			Status:        domain.PRStatusOpen,
+		}, []string{"u2"})
+		require.NoError(t, err)
+	}
+
+	_, err = service.DeactivateTeamUsers(ctx, domain.DeactivateTeamUsersRequest{
+		TeamName: "backend",
+		UserIDs:  []string{"u2"},
+	})
+	require.NoError(t, err)
+
+	// Оба PR не должны достаться одному и тому же ревьюверу
+	counts, err := repo.GetOpenReviewCounts(ctx, []string{"u3", "u4"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts["u3"])
+	assert.Equal(t, 1, counts["u4"])
+}
+
+func TestTeamService_DeactivateTeamUsers_SeniorRequired(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	_, err := service.CreateTeam(ctx, domain.CreateTeamRequest{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, Level: domain.UserLevelJunior},
+			{UserID: "u2", Username: "Bob", IsActive: true, Level: domain.UserLevelJunior},
+			{UserID: "u3", Username: "Charlie", IsActive: true, Level: domain.UserLevelJunior},
+			{UserID: "s1", Username: "Sam", IsActive: true, Level: domain.UserLevelSenior},
+			{UserID: "s2", Username: "Sara", IsActive: true, Level: domain.UserLevelLead},
+		},
+	})
+	require.NoError(t, err)
+
+	requireSenior := true
+	_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "backend", RequireSenior: &requireSenior})
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, repo.CreatePR(ctx, &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, CreatedAt: &now}, []string{"u2", "s1"}))
+
+	t.Run("replaces senior with a senior", func(t *testing.T) {
+		result, err := service.DeactivateTeamUsers(ctx, domain.DeactivateTeamUsersRequest{TeamName: "backend", UserIDs: []string{"s1"}})
+		require.NoError(t, err)
+		require.Len(t, result.ReassignedPRs, 1)
+		assert.ElementsMatch(t, []string{"u2", "s2"}, result.ReassignedPRs[0].NewReviewers)
+		assert.False(t, result.ReassignedPRs[0].SeniorMissing)
+	})
+
+	t.Run("reports PR left without a senior", func(t *testing.T) {
+		result, err := service.DeactivateTeamUsers(ctx, domain.DeactivateTeamUsersRequest{TeamName: "backend", UserIDs: []string{"s2"}})
+		require.NoError(t, err)
+		require.Len(t, result.ReassignedPRs, 1)
+		assert.True(t, result.ReassignedPRs[0].SeniorMissing)
+		assert.Len(t, result.ReassignedPRs[0].NewReviewers, 2)
+	})
+}
+
+func TestTeamService_SetTeamSettings(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewTeamService(repo, mockTx, newTestAssigner(t, repo, NewDefaultReviewerSelectors(), domain.CapacityPolicyFewer), mockLogger)
+
+	_, err := service.CreateTeam(ctx, domain.CreateTeamRequest{
+		TeamName: "platform",
+		Members:  []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	t.Run("returns defaults when settings are not set", func(t *testing.T) {
+		settings, err := service.GetTeamSettings(ctx, "platform")
+		require.NoError(t, err)
+		assert.Equal(t, 0, settings.MinReviewers)
+		assert.Equal(t, domain.DefaultMaxReviewers, settings.MaxReviewers)
+	})
+
+	t.Run("updates only provided fields", func(t *testing.T) {
+		maxReviewers := 3
+		settings, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", MaxReviewers: &maxReviewers})
+		require.NoError(t, err)
+		assert.Equal(t, 3, settings.MaxReviewers)
+
+		minReviewers := 2
+		settings, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", MinReviewers: &minReviewers})
+		require.NoError(t, err)
+		assert.Equal(t, 2, settings.MinReviewers)
+		assert.Equal(t, 3, settings.MaxReviewers)
+	})
+
+	t.Run("rejects min greater than max", func(t *testing.T) {
+		minReviewers := 5
+		_, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", MinReviewers: &minReviewers})
+		assert.Equal(t, domain.ErrInvalidTeamSettings, err)
+	})
+
+	t.Run("validates fallback teams", func(t *testing.T) {
+		_, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", FallbackTeams: []string{"platform"}})
+		assert.Equal(t, domain.ErrInvalidFallbackTeam, err)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", FallbackTeams: []string{"unknown"}})
+		assert.Equal(t, domain.ErrInvalidFallbackTeam, err)
+	})
+
+	t.Run("sets reviewers by size", func(t *testing.T) {
+		settings, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{
+			TeamName:        "platform",
+			ReviewersBySize: map[domain.PRSize]int{domain.PRSizeXS: 1, domain.PRSizeXL: 4},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, settings.ForSize(domain.PRSizeXL).MaxReviewers)
+		assert.Equal(t, 1, settings.ForSize(domain.PRSizeXS).MinReviewers)
+		assert.Equal(t, settings.MaxReviewers, settings.ForSize(domain.PRSizeM).MaxReviewers)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", ReviewersBySize: map[domain.PRSize]int{"huge": 5}})
+		assert.Equal(t, domain.ErrInvalidReviewersBySize, err)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", ReviewersBySize: map[domain.PRSize]int{domain.PRSizeS: 0}})
+		assert.Equal(t, domain.ErrInvalidReviewersBySize, err)
+	})
+
+	t.Run("sets size thresholds", func(t *testing.T) {
+		settings, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{
+			TeamName:       "platform",
+			SizeThresholds: map[domain.PRSize]int{domain.PRSizeM: 400, domain.PRSizeL: 2000},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.PRSize]int{domain.PRSizeXS: 10, domain.PRSizeS: 50, domain.PRSizeM: 400, domain.PRSizeL: 2000}, settings.SizeThresholds)
+		assert.Equal(t, domain.PRSizeM, settings.SizeForLines(300))
+		assert.Equal(t, domain.PRSizeL, settings.SizeForLines(1500))
+
+		for _, thresholds := range []map[domain.PRSize]int{
+			{domain.PRSizeXL: 5000},
+			{domain.PRSizeXS: 0},
+			{domain.PRSizeS: 500},
+		} {
+			_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", SizeThresholds: thresholds})
+			assert.Equal(t, domain.ErrInvalidSizeThresholds, err)
+		}
+	})
+
+	t.Run("sets merge policy", func(t *testing.T) {
+		settings, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{
+			TeamName:    "platform",
+			MergePolicy: &domain.MergePolicy{MinApprovals: 2, NoChangesRequested: true, RequiredApprovalTeam: "platform"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, settings.MergePolicy.MinApprovals)
+		assert.True(t, settings.MergePolicy.NoChangesRequested)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", MergePolicy: &domain.MergePolicy{MinApprovals: -1}})
+		assert.Equal(t, domain.ErrInvalidMergePolicy, err)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", MergePolicy: &domain.MergePolicy{RequiredApprovalTeam: "unknown"}})
+		assert.Equal(t, domain.ErrInvalidMergePolicy, err)
+	})
+
+	t.Run("sets review SLA", func(t *testing.T) {
+		settings, err := service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{
+			TeamName:  "platform",
+			ReviewSLA: &domain.ReviewSLA{FirstResponseHours: 24, Action: domain.SLAActionReassign},
+		})
+		require.NoError(t, err)
+		assert.True(t, settings.ReviewSLA.Enabled())
+		assert.Equal(t, domain.SLAActionReassign, settings.ReviewSLA.Action)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", ReviewSLA: &domain.ReviewSLA{FirstResponseHours: 24, Action: "page"}})
+		assert.Equal(t, domain.ErrInvalidReviewSLA, err)
+
+		_, err = service.SetTeamSettings(ctx, domain.SetTeamSettingsRequest{TeamName: "platform", ReviewSLA: &domain.ReviewSLA{FirstResponseHours: -1}})
+		assert.Equal(t, domain.ErrInvalidReviewSLA, err)
+	})
+
+	t.Run("returns error for unknown team", func(t *testing.T) {
+		_, err := service.GetTeamSettings(ctx, "unknown")
+		assert.Equal(t, domain.ErrTeamNotFound, err)
 	})
 }
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"slices"
+
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/infrastructure/logger"
 	"pr-reviewer/internal/infrastructure/storage"
@@ -50,7 +52,196 @@ func (s *UserService) SetUserActive(ctx context.Context, req domain.SetIsActiveR
 	return result, err
 }
 
-func (s *UserService) GetUserReviews(ctx context.Context, userID string) (*domain.UserReviewsResponse, error) {
+func (s *UserService) SetMaxOpenReviews(ctx context.Context, req domain.SetMaxOpenReviewsRequest) (*domain.User, error) {
+	if isNegative(req.MaxOpenReviews) {
+		return nil, domain.ErrInvalidCapacity
+	}
+
+	var result *domain.User
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetUserMaxOpenReviews(ctx, req.UserID, req.MaxOpenReviews); err != nil {
+			s.logger.Error("Failed to set user max open reviews", "error", err)
+			return err
+		}
+
+		user, err := s.repo.GetUser(ctx, req.UserID)
+		if err != nil {
+			s.logger.Error("Failed to get updated user", "error", err)
+			return err
+		}
+
+		result = user
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (s *UserService) SetLevel(ctx context.Context, req domain.SetUserLevelRequest) (*domain.User, error) {
+	if !req.Level.Valid() {
+		return nil, domain.ErrInvalidUserLevel
+	}
+
+	var result *domain.User
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetUserLevel(ctx, req.UserID, req.Level); err != nil {
+			s.logger.Error("Failed to set user level", "error", err)
+			return err
+		}
+
+		user, err := s.repo.GetUser(ctx, req.UserID)
+		if err != nil {
+			s.logger.Error("Failed to get updated user", "error", err)
+			return err
+		}
+
+		result = user
+
+		return nil
+	})
+
+	return result, err
+}
+
+// SetSkills заменяет теги пользователя целиком
+func (s *UserService) SetSkills(ctx context.Context, req domain.UserSkillsRequest) (*domain.User, error) {
+	return s.updateSkills(ctx, req, func(current, skills []string) []string {
+		return skills
+	})
+}
+
+func (s *UserService) AddSkills(ctx context.Context, req domain.UserSkillsRequest) (*domain.User, error) {
+	return s.updateSkills(ctx, req, func(current, skills []string) []string {
+		return append(append([]string{}, current...), skills...)
+	})
+}
+
+func (s *UserService) RemoveSkills(ctx context.Context, req domain.UserSkillsRequest) (*domain.User, error) {
+	return s.updateSkills(ctx, req, func(current, skills []string) []string {
+		removed := make(map[string]bool, len(skills))
+		for _, skill := range skills {
+			removed[skill] = true
+		}
+
+		kept := make([]string, 0, len(current))
+		for _, skill := range current {
+			if !removed[skill] {
+				kept = append(kept, skill)
+			}
+		}
+		return kept
+	})
+}
+
+func (s *UserService) updateSkills(ctx context.Context, req domain.UserSkillsRequest, apply func(current, skills []string) []string) (*domain.User, error) {
+	skills, ok := normalizeSkills(req.Skills)
+	if !ok {
+		return nil, domain.ErrInvalidSkill
+	}
+
+	var result *domain.User
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.repo.GetUser(ctx, req.UserID)
+		if err != nil {
+			s.logger.Error("Failed to get user", "error", err)
+			return err
+		}
+
+		updated, _ := normalizeSkills(apply(user.Skills, skills))
+		if updated == nil {
+			updated = []string{}
+		}
+
+		if err := s.repo.SetUserSkills(ctx, req.UserID, updated); err != nil {
+			s.logger.Error("Failed to set user skills", "error", err)
+			return err
+		}
+
+		user.Skills = updated
+		result = user
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (s *UserService) AddOutOfOffice(ctx context.Context, req domain.AddOutOfOfficeRequest) (*domain.OutOfOffice, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, domain.ErrInvalidOutOfOffice
+	}
+
+	var result *domain.OutOfOffice
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.repo.GetUser(ctx, req.UserID); err != nil {
+			s.logger.Error("Failed to get user", "error", err)
+			return err
+		}
+
+		period := &domain.OutOfOffice{
+			UserID:          req.UserID,
+			StartsAt:        req.StartsAt,
+			EndsAt:          req.EndsAt,
+			Reason:          req.Reason,
+			ReassignReviews: req.ReassignReviews,
+		}
+		if err := s.repo.CreateOutOfOffice(ctx, period); err != nil {
+			s.logger.Error("Failed to create out of office period", "error", err)
+			return err
+		}
+
+		result = period
+
+		return nil
+	})
+
+	return result, err
+}
+
+func (s *UserService) GetOutOfOffice(ctx context.Context, userID string) (*domain.OutOfOfficeResponse, error) {
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		s.logger.Error("Failed to get user", "error", err)
+		return nil, err
+	}
+
+	periods, err := s.repo.GetUserOutOfOffice(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get out of office periods", "error", err)
+		return nil, err
+	}
+
+	return &domain.OutOfOfficeResponse{
+		UserID:  userID,
+		Periods: periods,
+	}, nil
+}
+
+func (s *UserService) RemoveOutOfOffice(ctx context.Context, req domain.RemoveOutOfOfficeRequest) error {
+	if err := s.repo.DeleteOutOfOffice(ctx, req.ID); err != nil {
+		s.logger.Error("Failed to delete out of office period", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func isNegative(v *int) bool {
+	return v != nil && *v < 0
+}
+
+// GetUserReviews возвращает PR, где пользователь назначен ревьювером.
+// Если переданы labels, остаются только PR со всеми этими метками.
+func (s *UserService) GetUserReviews(ctx context.Context, userID string, labels []string) (*domain.UserReviewsResponse, error) {
+	labels, ok := normalizeLabels(labels)
+	if !ok {
+		return nil, domain.ErrInvalidLabel
+	}
+
 	_, err := s.repo.GetUser(ctx, userID)
 	if err != nil {
 		s.logger.Error("Failed to get user", "error", err)
@@ -63,14 +254,18 @@ func (s *UserService) GetUserReviews(ctx context.Context, userID string) (*domai
 		return nil, err
 	}
 
-	shortPRs := make([]domain.PullRequestShort, len(prs))
-	for i, pr := range prs {
-		shortPRs[i] = domain.PullRequestShort{
+	shortPRs := make([]domain.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		if !hasLabels(pr, labels) {
+			continue
+		}
+		shortPRs = append(shortPRs, domain.PullRequestShort{
 			PullRequestID:   pr.PullRequestID,
 			PullRequestName: pr.PullRequestName,
 			AuthorID:        pr.AuthorID,
 			Status:          pr.Status,
-		}
+			Labels:          pr.Labels,
+		})
 	}
 
 	return &domain.UserReviewsResponse{
@@ -78,3 +273,12 @@ func (s *UserService) GetUserReviews(ctx context.Context, userID string) (*domai
 		PullRequests: shortPRs,
 	}, nil
 }
+
+func hasLabels(pr domain.PullRequest, labels []string) bool {
+	for _, label := range labels {
+		if !slices.Contains(pr.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
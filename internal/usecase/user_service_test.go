@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -92,7 +93,7 @@ func TestUserService_GetUserReviews(t *testing.T) {
 	err = repo.CreatePR(context.Background(), pr, []string{"u2"})
 	require.NoError(t, err)
 
-	result, err := service.GetUserReviews(context.Background(), "u2")
+	result, err := service.GetUserReviews(context.Background(), "u2", nil)
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "u2", result.UserID)
@@ -100,6 +101,47 @@ func TestUserService_GetUserReviews(t *testing.T) {
 	assert.Equal(t, "pr-1", result.PullRequests[0].PullRequestID)
 }
 
+func TestUserService_GetUserReviews_Labels(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewUserService(repo, mockTx, mockLogger)
+
+	err := repo.CreateTeam(context.Background(), &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.CreatePR(context.Background(), &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, Labels: []string{"bug", "urgent"}}, []string{"u2"}))
+	require.NoError(t, repo.CreatePR(context.Background(), &domain.PullRequest{PullRequestID: "pr-2", AuthorID: "u1", Status: domain.PRStatusOpen, Labels: []string{"bug"}}, []string{"u2"}))
+	require.NoError(t, repo.CreatePR(context.Background(), &domain.PullRequest{PullRequestID: "pr-3", AuthorID: "u1", Status: domain.PRStatusOpen}, []string{"u2"}))
+
+	t.Run("keeps PRs with all labels", func(t *testing.T) {
+		result, err := service.GetUserReviews(context.Background(), "u2", []string{"Bug"})
+		require.NoError(t, err)
+		ids := make([]string, len(result.PullRequests))
+		for i, pr := range result.PullRequests {
+			ids[i] = pr.PullRequestID
+		}
+		assert.ElementsMatch(t, []string{"pr-1", "pr-2"}, ids)
+
+		result, err = service.GetUserReviews(context.Background(), "u2", []string{"bug", "urgent"})
+		require.NoError(t, err)
+		require.Len(t, result.PullRequests, 1)
+		assert.Equal(t, "pr-1", result.PullRequests[0].PullRequestID)
+		assert.Equal(t, []string{"bug", "urgent"}, result.PullRequests[0].Labels)
+	})
+
+	t.Run("rejects empty label", func(t *testing.T) {
+		_, err := service.GetUserReviews(context.Background(), "u2", []string{" "})
+		assert.Equal(t, domain.ErrInvalidLabel, err)
+	})
+}
+
 func TestUserService_GetUserReviews_NoPRs(t *testing.T) {
 	repo := memory.NewMemoryRepository()
 	mockLogger := new(MockLogger)
@@ -118,9 +160,160 @@ func TestUserService_GetUserReviews_NoPRs(t *testing.T) {
 	err := repo.CreateTeam(context.Background(), team, members)
 	require.NoError(t, err)
 
-	result, err := service.GetUserReviews(context.Background(), "u2")
+	result, err := service.GetUserReviews(context.Background(), "u2", nil)
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "u2", result.UserID)
 	assert.Empty(t, result.PullRequests)
 }
+
+func TestUserService_SetMaxOpenReviews(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewUserService(repo, mockTx, mockLogger)
+
+	team := &domain.Team{TeamName: "backend"}
+	members := []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	}
+	err := repo.CreateTeam(context.Background(), team, members)
+	require.NoError(t, err)
+
+	t.Run("sets limit", func(t *testing.T) {
+		limit := 3
+		result, err := service.SetMaxOpenReviews(context.Background(), domain.SetMaxOpenReviewsRequest{
+			UserID:         "u1",
+			MaxOpenReviews: &limit,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.MaxOpenReviews)
+		assert.Equal(t, 3, *result.MaxOpenReviews)
+	})
+
+	t.Run("clears limit", func(t *testing.T) {
+		result, err := service.SetMaxOpenReviews(context.Background(), domain.SetMaxOpenReviewsRequest{UserID: "u1"})
+		require.NoError(t, err)
+		assert.Nil(t, result.MaxOpenReviews)
+	})
+
+	t.Run("rejects negative limit", func(t *testing.T) {
+		limit := -1
+		_, err := service.SetMaxOpenReviews(context.Background(), domain.SetMaxOpenReviewsRequest{
+			UserID:         "u1",
+			MaxOpenReviews: &limit,
+		})
+		assert.Equal(t, domain.ErrInvalidCapacity, err)
+	})
+}
+
+func TestUserService_SetLevel(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewUserService(repo, mockTx, mockLogger)
+
+	err := repo.CreateTeam(context.Background(), &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	result, err := service.SetLevel(context.Background(), domain.SetUserLevelRequest{UserID: "u1", Level: domain.UserLevelSenior})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserLevelSenior, result.Level)
+
+	_, err = service.SetLevel(context.Background(), domain.SetUserLevelRequest{UserID: "u1", Level: "principal"})
+	assert.Equal(t, domain.ErrInvalidUserLevel, err)
+
+	_, err = service.SetLevel(context.Background(), domain.SetUserLevelRequest{UserID: "unknown", Level: domain.UserLevelJunior})
+	assert.Equal(t, domain.ErrUserNotFound, err)
+}
+
+func TestUserService_Skills(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewUserService(repo, mockTx, mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	t.Run("sets normalized skills", func(t *testing.T) {
+		result, err := service.SetSkills(ctx, domain.UserSkillsRequest{UserID: "u1", Skills: []string{"Go", " sql ", "go"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go", "sql"}, result.Skills)
+	})
+
+	t.Run("adds and removes skills", func(t *testing.T) {
+		result, err := service.AddSkills(ctx, domain.UserSkillsRequest{UserID: "u1", Skills: []string{"frontend", "sql"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go", "sql", "frontend"}, result.Skills)
+
+		result, err = service.RemoveSkills(ctx, domain.UserSkillsRequest{UserID: "u1", Skills: []string{"GO"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sql", "frontend"}, result.Skills)
+
+		user, err := repo.GetUser(ctx, "u1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sql", "frontend"}, user.Skills)
+	})
+
+	t.Run("rejects empty skill", func(t *testing.T) {
+		_, err := service.SetSkills(ctx, domain.UserSkillsRequest{UserID: "u1", Skills: []string{""}})
+		assert.Equal(t, domain.ErrInvalidSkill, err)
+	})
+
+	t.Run("returns error for unknown user", func(t *testing.T) {
+		_, err := service.SetSkills(ctx, domain.UserSkillsRequest{UserID: "unknown", Skills: []string{"go"}})
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+}
+
+func TestUserService_AddOutOfOffice(t *testing.T) {
+	repo := memory.NewMemoryRepository()
+	mockLogger := new(MockLogger)
+	mockTx := new(MockTransactionManager)
+	ctx := context.Background()
+	now := time.Now()
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	service := NewUserService(repo, mockTx, mockLogger)
+
+	err := repo.CreateTeam(ctx, &domain.Team{TeamName: "backend"}, []domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	t.Run("adds period", func(t *testing.T) {
+		period, err := service.AddOutOfOffice(ctx, domain.AddOutOfOfficeRequest{UserID: "u1", StartsAt: now, EndsAt: now.Add(24 * time.Hour), Reason: "vacation"})
+		require.NoError(t, err)
+		assert.NotZero(t, period.ID)
+
+		result, err := service.GetOutOfOffice(ctx, "u1")
+		require.NoError(t, err)
+		assert.Len(t, result.Periods, 1)
+	})
+
+	t.Run("rejects period that ends before it starts", func(t *testing.T) {
+		_, err := service.AddOutOfOffice(ctx, domain.AddOutOfOfficeRequest{UserID: "u1", StartsAt: now, EndsAt: now})
+		assert.Equal(t, domain.ErrInvalidOutOfOffice, err)
+	})
+
+	t.Run("returns error for unknown user", func(t *testing.T) {
+		_, err := service.AddOutOfOffice(ctx, domain.AddOutOfOfficeRequest{UserID: "unknown", StartsAt: now, EndsAt: now.Add(time.Hour)})
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+}
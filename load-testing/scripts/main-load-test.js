@@ -96,7 +96,7 @@ export default function (data) {
         const res = http.post(`${BASE_URL}/pullRequest/create`, payload, { headers: HEADERS });
         check(res, { 'PR создан (статус 201)': (r) => r.status === 201 });
         if (res.status === 201) {
-          assignedReviewers = res.json().pr.assigned_reviewers;
+          assignedReviewers = res.json().pr.assigned_reviewers.map((r) => r.user_id);
         }
       });
 